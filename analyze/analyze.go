@@ -0,0 +1,185 @@
+// Package analyze describes problems: their boards, units and unit
+// sources, and how much a perfect game could score at most.
+package analyze
+
+import "fmt"
+import "io"
+import "sort"
+import "strings"
+import "text/tabwriter"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+
+// Unit describes one of a problem's units.
+type Unit struct {
+	Size   int `json:"size"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// Rotations counts the distinct orientations, up to translation.
+	Rotations    int  `json:"rotations"`
+	PivotOutside bool `json:"pivotOutside"`
+}
+
+// Seed describes the units one of a problem's seeds spawns.
+type Seed struct {
+	Seed int `json:"seed"`
+	// Frequencies counts how often each unit comes up in the source.
+	Frequencies []int `json:"frequencies"`
+	// Bound is the most move score the seed could give, see MoveScoreBound.
+	Bound int `json:"bound"`
+}
+
+// Report describes a problem.
+type Report struct {
+	ProblemId int `json:"problemId"`
+	Width     int `json:"width"`
+	Height    int `json:"height"`
+	Filled    int `json:"filled"`
+	// Dead counts the empty cells of the start board no unit can reach.
+	Dead         int     `json:"dead"`
+	Density      float64 `json:"density"`
+	Units        []Unit  `json:"units"`
+	SourceLength int     `json:"sourceLength"`
+	Seeds        []Seed  `json:"seeds"`
+}
+
+// Bound is the average of the seeds' bounds, which is what the problem
+// could score at most without phrases.
+func (r Report) Bound() int {
+	if len(r.Seeds) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, s := range r.Seeds {
+		sum += s.Bound
+	}
+	return sum / len(r.Seeds)
+}
+
+// Analyze reports on the program's board, units and seeds.
+func Analyze(p game.Program) Report {
+	r := Report{
+		ProblemId:    p.Id,
+		Width:        p.Width,
+		Height:       p.Height,
+		Filled:       len(p.Filled),
+		Dead:         len(board.NewBoard(p.Height, p.Width, p.Filled).DeadCells()),
+		SourceLength: p.SourceLength,
+	}
+	if p.Width > 0 && p.Height > 0 {
+		r.Density = float64(len(p.Filled)) / float64(p.Width*p.Height)
+	}
+
+	for _, u := range p.Units {
+		r.Units = append(r.Units, Unit{
+			Size:         len(u.Members),
+			Width:        u.Width(),
+			Height:       u.Height(),
+			Rotations:    Rotations(u),
+			PivotOutside: !u.Contains(u.Pivot),
+		})
+	}
+
+	for _, seed := range p.SourceSeeds {
+		is := game.Drain(p.Source(seed))
+		s := Seed{Seed: seed, Frequencies: make([]int, len(p.Units)), Bound: MoveScoreBound(p, is)}
+		for _, i := range is {
+			s.Frequencies[i]++
+		}
+		r.Seeds = append(r.Seeds, s)
+	}
+	return r
+}
+
+// shape is the unit's members relative to the least of them in cube
+// coordinates, the same for all translations of the unit.
+func shape(u hex.Unit) string {
+	cs := []hex.Cube{}
+	for _, m := range u.Members {
+		cs = append(cs, m.Cube())
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].X < cs[j].X || (cs[i].X == cs[j].X && cs[i].Y < cs[j].Y)
+	})
+	parts := []string{}
+	for _, c := range cs {
+		d := c.Sub(cs[0])
+		parts = append(parts, fmt.Sprintf("%v,%v", d.X, d.Y))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Rotations counts the distinct orientations of the unit.
+func Rotations(u hex.Unit) int {
+	seen := map[string]bool{}
+	for i := 0; i < 6; i++ {
+		seen[shape(u)] = true
+		u = u.Move(hex.RC)
+	}
+	return len(seen)
+}
+
+// MoveScoreBound is a crude upper bound on the move score of placing the
+// units with the given indexes: every unit scores its size, and the rows
+// the filled cells and units could complete at most are cleared as few
+// units at a time as their heights allow, each with the biggest bonus
+// for rows cleared by the unit before.
+func MoveScoreBound(p game.Program, is []int) int {
+	if p.Width == 0 {
+		return 0
+	}
+	cells := len(p.Filled)
+	heights := []int{}
+	score := 0
+	for _, i := range is {
+		u := p.Units[i]
+		cells += len(u.Members)
+		score += len(u.Members)
+		heights = append(heights, u.Height())
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(heights)))
+
+	rows := cells / p.Width
+	maxHeight := 0
+	if len(heights) > 0 {
+		maxHeight = heights[0]
+	}
+	for _, h := range heights {
+		ls := h
+		if ls > rows {
+			ls = rows
+		}
+		rows -= ls
+		points := 100 * (1 + ls) * ls / 2
+		score += points
+		if maxHeight > 1 {
+			score += (maxHeight - 1) * points / 10
+		}
+	}
+	return score
+}
+
+// Write prints the reports, a line per problem followed by its units and
+// seeds.
+func Write(w io.Writer, rs []Report) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for _, r := range rs {
+		fmt.Fprintf(tw, "problem %v: %vx%v, filled %v (%.0f%%), dead %v, %v units, %v seeds, source %v, bound %v\n",
+			r.ProblemId, r.Width, r.Height, r.Filled, 100*r.Density, r.Dead, len(r.Units), len(r.Seeds), r.SourceLength, r.Bound())
+		for i, u := range r.Units {
+			pivot := ""
+			if u.PivotOutside {
+				pivot = "pivot outside"
+			}
+			fmt.Fprintf(tw, "  unit %v\tsize %v\t%vx%v\trotations %v\t%v\n", i, u.Size, u.Width, u.Height, u.Rotations, pivot)
+		}
+		// seeds line up on their own
+		tw.Flush()
+		for _, s := range r.Seeds {
+			fmt.Fprintf(tw, "  seed %v\tbound %v\tunits %v\n", s.Seed, s.Bound, s.Frequencies)
+		}
+		tw.Flush()
+	}
+}
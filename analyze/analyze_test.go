@@ -0,0 +1,80 @@
+package analyze
+
+import "bytes"
+import "fmt"
+import "io/ioutil"
+import "strings"
+import "testing"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+import "github.com/mneise/icfp15/solver"
+
+func unit(pivot hex.Cell, cs ...hex.Cell) hex.Unit {
+	return hex.Unit{Members: cs, Pivot: pivot}
+}
+
+func TestRotations(t *testing.T) {
+	data := []struct {
+		u        hex.Unit
+		expected int
+	}{
+		{unit(hex.Cell{X: 0, Y: 0}, hex.Cell{X: 0, Y: 0}), 1},
+		{unit(hex.Cell{X: 5, Y: 5}, hex.Cell{X: 0, Y: 0}), 1},
+		{unit(hex.Cell{X: 0, Y: 0}, hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}), 3},
+		{unit(hex.Cell{X: 1, Y: 0}, hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}, hex.Cell{X: 2, Y: 0}), 3},
+		{unit(hex.Cell{X: 0, Y: 0}, hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}, hex.Cell{X: 0, Y: 1}), 2},
+		{unit(hex.Cell{X: 0, Y: 0}, hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}, hex.Cell{X: 1, Y: 1}), 6},
+	}
+
+	for _, d := range data {
+		if actual := Rotations(d.u); actual != d.expected {
+			t.Errorf("Expected %v to have %v rotations, but was: %v", d.u, d.expected, actual)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	for _, id := range []int{0, 1, 6} {
+		in, err := ioutil.ReadFile(fmt.Sprintf("../p%v.json", id))
+		if err != nil {
+			t.Fatalf("can't open problem: %v", err)
+		}
+		p := *game.ReadProgram(in)
+		r := Analyze(p)
+
+		if len(r.Units) != len(p.Units) || len(r.Seeds) != len(p.SourceSeeds) {
+			t.Errorf("Expected a report of every unit and seed, got: %+v", r)
+		}
+		for i, s := range r.Seeds {
+			sum := 0
+			for _, f := range s.Frequencies {
+				sum += f
+			}
+			if sum != p.SourceLength {
+				t.Errorf("Expected frequencies of seed %v to add up to %v, got: %v", s.Seed, p.SourceLength, s.Frequencies)
+			}
+			if g := solver.Play(p, p.SourceSeeds[i], solver.Options{}); g.MoveScore > s.Bound {
+				t.Errorf("Problem %v seed %v scored %v over its bound %v", id, s.Seed, g.MoveScore, s.Bound)
+			}
+		}
+
+		var b bytes.Buffer
+		Write(&b, []Report{r})
+		if !strings.HasPrefix(b.String(), fmt.Sprintf("problem %v: ", id)) {
+			t.Errorf("Unexpected report:\n%v", b.String())
+		}
+	}
+}
+
+func TestAnalyzeDeadCells(t *testing.T) {
+	p := game.Program{
+		Units:  []hex.Unit{unit(hex.Cell{X: 0, Y: 0}, hex.Cell{X: 0, Y: 0})},
+		Width:  3,
+		Height: 3,
+		Filled: []hex.Cell{hex.Cell{X: 1, Y: 1}, hex.Cell{X: 2, Y: 1}, hex.Cell{X: 1, Y: 2}},
+	}
+	if r := Analyze(p); r.Dead != 1 {
+		t.Errorf("Expected the walled in bottom right cell to be dead, got: %+v", r)
+	}
+}
@@ -0,0 +1,156 @@
+// Package archive keeps the best verified solution for every problem and
+// seed on disk.
+package archive
+
+import "encoding/json"
+import "fmt"
+import "io/ioutil"
+import "os"
+import "path/filepath"
+import "sort"
+import "strconv"
+import "strings"
+import "time"
+
+import "github.com/mneise/icfp15/game"
+
+// Entry is the best known solution for one seed of a problem.
+type Entry struct {
+	ProblemId int       `json:"problemId"`
+	Seed      int       `json:"seed"`
+	Score     int       `json:"score"`
+	Solution  string    `json:"solution"`
+	Settings  string    `json:"settings"`
+	Time      time.Time `json:"time"`
+	// Submitted is set once the contest server accepted the solution.
+	Submitted bool `json:"submitted"`
+}
+
+// Archive keeps one json file per problem and seed below Dir, as
+// Dir/p<problemId>/s<seed>.json.
+type Archive struct {
+	Dir string
+}
+
+func (e Entry) Output() game.Output {
+	return game.Output{
+		ProblemId: e.ProblemId,
+		Seed:      e.Seed,
+		Tag:       e.Settings,
+		Solution:  e.Solution,
+	}
+}
+
+func (a Archive) path(problem, seed int) string {
+	return filepath.Join(a.Dir, fmt.Sprintf("p%v", problem), fmt.Sprintf("s%v.json", seed))
+}
+
+func (a Archive) Best(problem, seed int) (Entry, bool) {
+	e := Entry{}
+	in, err := ioutil.ReadFile(a.path(problem, seed))
+	if err != nil {
+		return e, false
+	}
+	if err := json.Unmarshal(in, &e); err != nil {
+		return e, false
+	}
+	return e, true
+}
+
+// Add verifies the solution with game.Simulate and stores it if it beats the
+// archived one, not submitted yet. It reports the verified entry and
+// whether it was stored.
+func (a Archive) Add(p game.Program, o game.Output, settings string) (Entry, bool, error) {
+	r := game.Simulate(p, o.Seed, o.Solution)
+	e := Entry{
+		ProblemId: o.ProblemId,
+		Seed:      o.Seed,
+		Score:     r.Score,
+		Solution:  o.Solution,
+		Settings:  settings,
+		Time:      time.Now(),
+	}
+	if r.Err != nil {
+		return e, false, r.Err
+	}
+
+	if old, ok := a.Best(o.ProblemId, o.Seed); ok && old.Score >= e.Score {
+		return e, false, nil
+	}
+
+	if err := a.write(e); err != nil {
+		return e, false, err
+	}
+	return e, true, nil
+}
+
+func (a Archive) write(e Entry) error {
+	data, err := json.MarshalIndent(&e, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(a.path(e.ProblemId, e.Seed)), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(a.path(e.ProblemId, e.Seed), data, 0644)
+}
+
+// MarkSubmitted records that the solution was submitted, if it still is
+// the archived one.
+func (a Archive) MarkSubmitted(o game.Output) error {
+	e, ok := a.Best(o.ProblemId, o.Seed)
+	if !ok || e.Solution != o.Solution || e.Submitted {
+		return nil
+	}
+	e.Submitted = true
+	return a.write(e)
+}
+
+// Unsubmitted returns the archived solutions of all problems that weren't
+// submitted yet, ordered by problem and seed.
+func (a Archive) Unsubmitted() []game.Output {
+	outs := []game.Output{}
+	for _, problem := range a.problems() {
+		for _, s := range a.seeds(problem) {
+			if e, ok := a.Best(problem, s); ok && !e.Submitted {
+				outs = append(outs, e.Output())
+			}
+		}
+	}
+	return outs
+}
+
+func (a Archive) problems() []int {
+	ds, _ := filepath.Glob(filepath.Join(a.Dir, "p*"))
+	return sortedIds(ds, "p", "")
+}
+
+func (a Archive) seeds(problem int) []int {
+	fs, _ := filepath.Glob(filepath.Join(a.Dir, fmt.Sprintf("p%v", problem), "s*.json"))
+	return sortedIds(fs, "s", ".json")
+}
+
+// sortedIds parses the numbers between prefix and suffix of the base
+// names of the paths.
+func sortedIds(paths []string, prefix, suffix string) []int {
+	ids := []int{}
+	for _, f := range paths {
+		id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f), prefix), suffix))
+		if err == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// BestOutputs returns the archived solutions of a problem, ordered by seed.
+func (a Archive) BestOutputs(problem int) []game.Output {
+	outs := []game.Output{}
+	for _, s := range a.seeds(problem) {
+		if e, ok := a.Best(problem, s); ok {
+			outs = append(outs, e.Output())
+		}
+	}
+	return outs
+}
@@ -0,0 +1,62 @@
+package archive
+
+import "os"
+import "testing"
+
+import "io/ioutil"
+import "github.com/mneise/icfp15/game"
+
+func TestArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatalf("can't create archive dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := game.LoadProblems("../testdata")[90]
+	a := Archive{Dir: dir}
+
+	data := []struct {
+		out      game.Output
+		score    int
+		improved bool
+		err      bool
+	}{
+		{out: game.Output{ProblemId: 90, Seed: 1, Solution: "ll"}, score: 101, improved: true},
+		{out: game.Output{ProblemId: 90, Seed: 1, Solution: "l"}, score: 0, improved: false},
+		{out: game.Output{ProblemId: 90, Seed: 1, Solution: "bp"}, err: true},
+		{out: game.Output{ProblemId: 90, Seed: 1, Solution: "ei!"}, score: 407, improved: true},
+		{out: game.Output{ProblemId: 90, Seed: 0, Solution: "ll"}, score: 101, improved: true},
+		{out: game.Output{ProblemId: 90, Seed: 0, Solution: "ll"}, score: 101, improved: false},
+	}
+
+	for _, d := range data {
+		e, improved, err := a.Add(p, d.out, "test")
+		if (err != nil) != d.err || improved != d.improved || (!d.err && e.Score != d.score) {
+			t.Errorf("Failed to archive %v, got: %v %v %v", d.out, e, improved, err)
+		}
+	}
+
+	actual := a.BestOutputs(90)
+	if len(actual) != 2 || actual[0].Seed != 0 || actual[1].Solution != "ei!" || actual[1].Tag != "test" {
+		t.Errorf("Unexpected best outputs: %v", actual)
+	}
+
+	if _, ok := a.Best(8, 0); ok {
+		t.Errorf("Expected no entry for unknown problem")
+	}
+
+	// only accepted solutions stop being resent
+	a.MarkSubmitted(game.Output{ProblemId: 90, Seed: 0, Solution: "ll"})
+	a.MarkSubmitted(game.Output{ProblemId: 90, Seed: 1, Solution: "ll"})
+	if actual := a.Unsubmitted(); len(actual) != 1 || actual[0].Seed != 1 || actual[0].Solution != "ei!" {
+		t.Errorf("Expected only seed 1 to be left to submit, got: %v", actual)
+	}
+	if e, _ := a.Best(90, 0); !e.Submitted {
+		t.Errorf("Expected seed 0 to be marked as submitted, got: %+v", e)
+	}
+	a.Add(p, game.Output{ProblemId: 90, Seed: 0, Solution: "ei!"}, "test")
+	if actual := a.Unsubmitted(); len(actual) != 2 {
+		t.Errorf("Expected the improvement on seed 0 to be submitted again, got: %v", actual)
+	}
+}
@@ -0,0 +1,143 @@
+// Package bench solves and verifies problems in bulk and reports the
+// scores, optionally against an earlier report.
+package bench
+
+import "fmt"
+import "io"
+import "strings"
+import "sync"
+import "text/tabwriter"
+import "time"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/phrases"
+import "github.com/mneise/icfp15/solver"
+
+// Row is the verified outcome of solving one seed of a problem.
+type Row struct {
+	ProblemId    int           `json:"problemId"`
+	Seed         int           `json:"seed"`
+	MoveScore    int           `json:"moveScore"`
+	PowerScore   int           `json:"powerScore"`
+	Placed       int           `json:"placed"`
+	Dead         int           `json:"dead"`
+	SourceLength int           `json:"sourceLength"`
+	Runtime      time.Duration `json:"runtime"`
+	Error        string        `json:"error,omitempty"`
+}
+
+func (r Row) Score() int {
+	return r.MoveScore + r.PowerScore
+}
+
+type key struct {
+	problem int
+	seed    int
+}
+
+// Source makes the unit source for a seed of a program.
+type Source func(p game.Program, seed int) game.UnitSource
+
+// Run solves every seed of the programs, up to jobs seeds in parallel
+// but at least one, and verifies each solution with game.Simulate.
+func Run(ps []game.Program, s solver.Solver, jobs int) []Row {
+	return RunSource(ps, s, jobs, nil)
+}
+
+// RunSource is Run playing the units of src instead of the contest's,
+// nil means the contest's.
+func RunSource(ps []game.Program, s solver.Solver, jobs int, src Source) []Row {
+	type task struct {
+		p    game.Program
+		seed int
+	}
+	tasks := []task{}
+	for _, p := range ps {
+		for _, s := range p.SourceSeeds {
+			tasks = append(tasks, task{p, s})
+		}
+	}
+
+	rows := make([]Row, len(tasks))
+	next := make(chan int)
+	wg := sync.WaitGroup{}
+	for j := 0; j < jobs || j == 0; j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				t := tasks[i]
+				in := solver.Input{Program: t.p, Seed: t.seed}
+				us := t.p.Source(t.seed)
+				if src != nil {
+					in.Units = game.Drain(src(t.p, t.seed))
+					us = game.NewFixedSource(in.Units)
+				}
+				sol := s.Solve(in)
+				r := game.SimulateSource(t.p, us, sol.Commands, phrases.PowerPhrases)
+				rows[i] = Row{
+					ProblemId:    t.p.Id,
+					Seed:         t.seed,
+					MoveScore:    r.MoveScore,
+					PowerScore:   r.PowerScore,
+					Placed:       r.Placed,
+					Dead:         r.Dead,
+					SourceLength: t.p.SourceLength,
+					Runtime:      sol.Stats.Elapsed,
+				}
+				switch {
+				case r.Err != nil:
+					rows[i].Error = r.Err.Error()
+				case r.Score != sol.Stats.MoveScore+sol.Stats.PowerScore:
+					rows[i].Error = fmt.Sprintf("solver claims %v", sol.Stats.MoveScore+sol.Stats.PowerScore)
+				}
+			}
+		}()
+	}
+	for i := range tasks {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return rows
+}
+
+// WriteReport prints a table of the rows, comparing each one against
+// the same problem and seed in old, if given.
+func WriteReport(w io.Writer, rows []Row, old []Row) (regressions int) {
+	prev := map[key]Row{}
+	for _, r := range old {
+		prev[key{r.ProblemId, r.Seed}] = r
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "problem\tseed\tmove\tpower\tscore\tunits\tdead\truntime\t\t")
+	total := 0
+	totalOld := 0
+	for _, r := range rows {
+		total += r.Score()
+		note := r.Error
+		if o, ok := prev[key{r.ProblemId, r.Seed}]; ok {
+			totalOld += o.Score()
+			switch {
+			case r.Score() < o.Score():
+				note = strings.TrimSpace(fmt.Sprintf("REGRESSION %+d %v", r.Score()-o.Score(), note))
+				regressions++
+			case r.Score() > o.Score():
+				note = strings.TrimSpace(fmt.Sprintf("improved %+d %v", r.Score()-o.Score(), note))
+			}
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v/%v\t%v\t%v\t%v\t\n",
+			r.ProblemId, r.Seed, r.MoveScore, r.PowerScore, r.Score(),
+			r.Placed, r.SourceLength, r.Dead, r.Runtime.Round(time.Millisecond), note)
+	}
+	if len(old) > 0 {
+		fmt.Fprintf(tw, "total\t\t\t\t%v\t\t\t\t%+d\t\n", total, total-totalOld)
+	} else {
+		fmt.Fprintf(tw, "total\t\t\t\t%v\t\t\t\t\t\n", total)
+	}
+	tw.Flush()
+
+	return regressions
+}
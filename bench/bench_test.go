@@ -0,0 +1,58 @@
+package bench
+
+import "bytes"
+import "strings"
+import "testing"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/solver"
+
+func TestBenchVerifiesSolutions(t *testing.T) {
+	p := *game.ReadProgram([]byte(`{"id": 9, "units": [{"members": [{"x": 0, "y": 0}], "pivot": {"x": 0, "y": 0}}], "width": 3, "height": 3, "filled": [], "sourceLength": 5, "sourceSeeds": [0, 1]}`))
+	s, _ := solver.New("greedy", solver.Options{})
+
+	// no jobs still runs one
+	for _, jobs := range []int{2, 0} {
+		rows := Run([]game.Program{p}, s, jobs)
+		if len(rows) != 2 || rows[0].Seed != 0 || rows[1].Seed != 1 {
+			t.Errorf("Expected one row per seed, got: %v", rows)
+		}
+		for _, r := range rows {
+			if r.Error != "" || r.Placed != 5 || r.SourceLength != 5 || r.Score() == 0 {
+				t.Errorf("Unexpected bench row: %+v", r)
+			}
+		}
+	}
+}
+
+func TestBenchReportRegressions(t *testing.T) {
+	old := []Row{
+		Row{ProblemId: 1, Seed: 0, MoveScore: 100},
+		Row{ProblemId: 1, Seed: 5, MoveScore: 100},
+	}
+	rows := []Row{
+		Row{ProblemId: 1, Seed: 0, MoveScore: 90},
+		Row{ProblemId: 1, Seed: 5, MoveScore: 100, PowerScore: 306},
+		Row{ProblemId: 2, Seed: 0, MoveScore: 10},
+	}
+
+	w := &bytes.Buffer{}
+	if actual := WriteReport(w, rows, old); actual != 1 {
+		t.Errorf("Expected one regression, got %v:\n%v", actual, w)
+	}
+	if !strings.Contains(w.String(), "REGRESSION -10") || !strings.Contains(w.String(), "improved +306") {
+		t.Errorf("Expected regressions and improvements in report:\n%v", w)
+	}
+}
+
+func TestBenchRunsSource(t *testing.T) {
+	p := *game.ReadProgram([]byte(`{"id": 9, "units": [{"members": [{"x": 0, "y": 0}], "pivot": {"x": 0, "y": 0}}, {"members": [{"x": 0, "y": 0}, {"x": 1, "y": 0}], "pivot": {"x": 0, "y": 0}}], "width": 3, "height": 3, "filled": [], "sourceLength": 5, "sourceSeeds": [0]}`))
+	s, _ := solver.New("greedy", solver.Options{})
+	rows := RunSource([]game.Program{p}, s, 1, func(game.Program, int) game.UnitSource {
+		return game.NewFixedSource([]int{1, 1})
+	})
+
+	if len(rows) != 1 || rows[0].Error != "" || rows[0].Placed != 2 {
+		t.Errorf("Expected both dominoes to be placed, got: %+v", rows)
+	}
+}
@@ -0,0 +1,207 @@
+// Package board implements the game board: which cells are filled, where
+// units spawn and may move, and clearing full rows.
+package board
+
+import "github.com/mneise/icfp15/hex"
+
+// Board holds the filled state of every cell, indexed by row then column.
+type Board [][]bool
+
+func NewBoard(height int, width int, cells []hex.Cell) Board {
+	b := make([][]bool, height)
+	for i := range b {
+		b[i] = make([]bool, width)
+	}
+
+	for _, c := range cells {
+		b[c.Y][c.X] = true
+	}
+
+	return b
+}
+
+// FillCells returns a copy of the board with the cells filled.
+func (b Board) FillCells(cells []hex.Cell) Board {
+	nb := NewBoard(b.Height(), b.Width(), cells)
+	for y, row := range b {
+		for x, cell := range row {
+			if cell {
+				nb[y][x] = true
+			}
+		}
+	}
+
+	return nb
+}
+
+func (b Board) String() (s string) {
+
+	for ri, r := range b {
+		if ri%2 == 1 {
+			s += " "
+		}
+
+		for ci, c := range r {
+			if c {
+				s += "⬢"
+			} else {
+				s += "⬡"
+			}
+			if ci < len(r)-1 {
+				s += " "
+			}
+		}
+
+		if ri < len(b)-1 {
+			s += "\n"
+		}
+	}
+
+	return s
+}
+
+func (b Board) Width() int {
+	if len(b) > 0 {
+		return len(b[0])
+	}
+
+	return 0
+}
+
+func (b Board) Height() int {
+	return len(b)
+}
+
+// IsValidCell reports whether the cell is on the board and empty.
+func (b Board) IsValidCell(c hex.Cell) bool {
+	return c.X >= 0 &&
+		c.X < b.Width() &&
+		c.Y >= 0 &&
+		c.Y < b.Height() &&
+		!b.isCellFull(c)
+}
+
+// A unit is in a valid location if all of its cells are on empty board
+// cells. Note that a unit's pivot point need not be on a board cell.
+func (b Board) IsValid(u hex.Unit) bool {
+	for _, c := range u.Members {
+		if !b.IsValidCell(c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsFilled reports whether the cell is on the board and filled.
+func (b Board) IsFilled(c hex.Cell) bool {
+	return c.X >= 0 &&
+		c.X < b.Width() &&
+		c.Y >= 0 &&
+		c.Y < b.Height() &&
+		b.isCellFull(c)
+}
+
+func (b Board) isCellFull(c hex.Cell) bool {
+	return b[c.Y][c.X]
+}
+
+func (b Board) IsRowFull(r int) bool {
+	for _, c := range b[r] {
+		if !c {
+			return false
+		}
+	}
+	return true
+}
+
+func (b Board) CountFullRows() int {
+	count := 0
+	for r := range b {
+		if b.IsRowFull(r) {
+			count += 1
+		}
+	}
+	return count
+}
+
+// StartLocation is where a unit spawns: centered, rounded to the left, on
+// the top row.
+func (b Board) StartLocation(u hex.Unit) hex.Unit {
+	// move to right
+	minXCell := u.MinXCell()
+	offset := (b.Width() - u.Width()) / 2
+	nc := minXCell.ShiftX(0 - minXCell.X + offset)
+	u = u.MoveTo(nc, minXCell)
+
+	// move up
+	minYCell := u.MinYCell()
+	offset = 0 - minYCell.Y
+	nc = minYCell.ShiftY(offset)
+	u = u.MoveTo(nc, minYCell)
+
+	return u
+}
+
+// ClearFullRows removes full rows, shifting the rows above down, and
+// returns the new board with the number of rows cleared.
+func (b Board) ClearFullRows() (Board, int) {
+	var cleared int
+	nb := NewBoard(b.Height(), b.Width(), []hex.Cell{})
+	copy(nb, b)
+	for i := b.Height() - 1; i >= 0; i-- {
+		if nb.IsRowFull(i) {
+			cleared++
+			if i == b.Height()-1 {
+				nb = nb[:i]
+			} else {
+				nb = append(nb[:i], nb[i+1:]...)
+			}
+			r := make([]bool, b.Width())
+			nb = append([][]bool{r}, nb...)
+			i++
+		}
+	}
+	return nb, cleared
+}
+
+// DeadCells are the empty cells no cell moving east, west, south east and
+// south west can reach from an empty cell of the top row. Units move the
+// same way, so they can't fill them until clearing rows opens them up,
+// rotations aside.
+func (b Board) DeadCells() []hex.Cell {
+	seen := make([][]bool, b.Height())
+	for y := range seen {
+		seen[y] = make([]bool, b.Width())
+	}
+	todo := []hex.Cell{}
+	if b.Height() > 0 {
+		for x := range b[0] {
+			if !b[0][x] {
+				seen[0][x] = true
+				todo = append(todo, hex.Cell{X: x, Y: 0})
+			}
+		}
+	}
+	for len(todo) > 0 {
+		c := todo[len(todo)-1]
+		todo = todo[:len(todo)-1]
+		for _, m := range []hex.Move{hex.E, hex.W, hex.SE, hex.SW} {
+			n := c.Move(m)
+			if b.IsValidCell(n) && !seen[n.Y][n.X] {
+				seen[n.Y][n.X] = true
+				todo = append(todo, n)
+			}
+		}
+	}
+
+	dead := []hex.Cell{}
+	for y := range b {
+		for x := range b[y] {
+			if !b[y][x] && !seen[y][x] {
+				dead = append(dead, hex.Cell{X: x, Y: y})
+			}
+		}
+	}
+	return dead
+}
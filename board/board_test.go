@@ -0,0 +1,237 @@
+package board
+
+import "testing"
+
+import "github.com/mneise/icfp15/hex"
+
+func equalsBoard(actual Board, expected Board) bool {
+	for y := range expected {
+		for x := range expected[y] {
+			if expected[y][x] != actual[y][x] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func TestStartLocation(t *testing.T) {
+	atoms := []struct {
+		board    Board
+		atom     hex.Unit
+		expected hex.Unit
+	}{
+		{
+			board:    NewBoard(2, 2, []hex.Cell{}),
+			atom:     hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}},
+			expected: hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}},
+		},
+		{
+			board:    NewBoard(2, 3, []hex.Cell{}),
+			atom:     hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}},
+			expected: hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 1, Y: 0}},
+		},
+		{
+			board:    NewBoard(2, 5, []hex.Cell{}),
+			atom:     hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 1}, hex.Cell{X: 1, Y: 1}}, Pivot: hex.Cell{X: 0, Y: 0}},
+			expected: hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 0}, hex.Cell{X: 2, Y: 0}}, Pivot: hex.Cell{X: 1, Y: -1}},
+		},
+	}
+
+	for _, data := range atoms {
+		actual := data.board.StartLocation(data.atom)
+
+		if len(actual.Members) != len(data.expected.Members) {
+			t.Errorf("Not the same number of Members: %v expected %v", actual, data.expected)
+			return
+		}
+
+		for i, member := range data.expected.Members {
+			if actual.Members[i] != member {
+				t.Errorf("Failed identify height: %v expected %v", actual, data.expected)
+			}
+		}
+	}
+
+}
+
+func TestFillBoard(t *testing.T) {
+	b := NewBoard(2, 2, []hex.Cell{hex.Cell{X: 1, Y: 1}})
+	actual := b.FillCells([]hex.Cell{hex.Cell{X: 0, Y: 0}})
+
+	if !actual[0][0] || !actual[1][1] || actual[0][1] || actual[1][0] {
+		t.Errorf("Failed to read fill board got: %v", actual)
+	}
+
+}
+
+func TestBoardString(t *testing.T) {
+	data := []struct {
+		board    Board
+		expected string
+	}{
+		{
+			board: NewBoard(2, 2, []hex.Cell{}),
+			expected: `⬡ ⬡
+ ⬡ ⬡`,
+		},
+		{
+			board: NewBoard(2, 2, []hex.Cell{hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 1}}),
+			expected: `⬢ ⬡
+ ⬡ ⬢`,
+		},
+		{
+			board: NewBoard(3, 3, []hex.Cell{hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 1}}),
+			expected: `⬢ ⬡ ⬡
+ ⬡ ⬢ ⬡
+⬡ ⬡ ⬡`,
+		},
+	}
+
+	for _, d := range data {
+		if actual := d.board.String(); d.expected != actual {
+			t.Errorf("weird string for board, actual\n%v\nexpected:\n%v\n", actual, d.expected)
+		}
+	}
+}
+
+func TestIsValidUnit(t *testing.T) {
+	unit := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	board := NewBoard(2, 2, []hex.Cell{})
+
+	if !board.IsValid(unit) {
+		t.Errorf("Expected unit: %v to be valid on board %v, but was invalid", unit, board)
+	}
+
+	unit = hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	board = NewBoard(2, 2, []hex.Cell{hex.Cell{X: 1, Y: 0}})
+
+	if board.IsValid(unit) {
+		t.Errorf("Expected unit: %v to be invalid on board %v, but was valid", unit, board)
+	}
+
+	unit = hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}, hex.Cell{X: -1, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	board = NewBoard(2, 2, []hex.Cell{})
+
+	if board.IsValid(unit) {
+		t.Errorf("Expected unit: %v to be invalid on board %v, but was valid", unit, board)
+	}
+}
+
+func TestIsRowFull(t *testing.T) {
+	b := NewBoard(2, 2, []hex.Cell{hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}})
+
+	if !b.IsRowFull(0) {
+		t.Errorf("Expected row to be full: %v but wasn't.", b[0])
+	}
+
+	if b.IsRowFull(1) {
+		t.Errorf("Expected row not to be full: %v but was.", b[1])
+	}
+}
+
+func TestClearFullRows(t *testing.T) {
+	// bottom row is full
+	b := NewBoard(2, 2, []hex.Cell{
+		hex.Cell{X: 0, Y: 0},
+		hex.Cell{X: 0, Y: 1}, hex.Cell{X: 1, Y: 1}})
+	expected := NewBoard(2, 2, []hex.Cell{hex.Cell{X: 0, Y: 1}})
+	actual, _ := b.ClearFullRows()
+
+	if !equalsBoard(expected, actual) {
+		t.Errorf("Expected cleared board to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	// top row is full
+	b = NewBoard(3, 2, []hex.Cell{
+		hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0},
+		hex.Cell{X: 1, Y: 1},
+		hex.Cell{X: 0, Y: 2}})
+	expected = NewBoard(3, 2, []hex.Cell{
+		hex.Cell{X: 1, Y: 1}, hex.Cell{X: 0, Y: 2}})
+	actual, _ = b.ClearFullRows()
+
+	if !equalsBoard(expected, actual) {
+		t.Errorf("Expected cleared board to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	// two rows are full
+	b = NewBoard(3, 2, []hex.Cell{
+		hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0},
+		hex.Cell{X: 1, Y: 1},
+		hex.Cell{X: 0, Y: 2}, hex.Cell{X: 1, Y: 2}})
+	expected = NewBoard(3, 2, []hex.Cell{hex.Cell{X: 1, Y: 2}})
+	actual, _ = b.ClearFullRows()
+
+	if !equalsBoard(expected, actual) {
+		t.Errorf("Expected cleared board to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	// all rows are full
+	b = NewBoard(3, 2, []hex.Cell{
+		hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0},
+		hex.Cell{X: 0, Y: 1}, hex.Cell{X: 1, Y: 1},
+		hex.Cell{X: 0, Y: 2}, hex.Cell{X: 1, Y: 2}})
+	expected = NewBoard(3, 2, []hex.Cell{})
+	actual, _ = b.ClearFullRows()
+
+	if !equalsBoard(expected, actual) {
+		t.Errorf("Expected cleared board to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	// all cells are empty
+	b = NewBoard(3, 2, []hex.Cell{})
+	expected = NewBoard(3, 2, []hex.Cell{})
+	actual, _ = b.ClearFullRows()
+
+	if !equalsBoard(expected, actual) {
+		t.Errorf("Expected cleared board to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	// all rows are empty
+	b = NewBoard(3, 2, []hex.Cell{hex.Cell{X: 1, Y: 0}, hex.Cell{X: 1, Y: 2}})
+	expected = NewBoard(3, 2, []hex.Cell{hex.Cell{X: 1, Y: 0}, hex.Cell{X: 1, Y: 2}})
+	actual, _ = b.ClearFullRows()
+
+	if !equalsBoard(expected, actual) {
+		t.Errorf("Expected cleared board to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	// some rows are full
+	b = NewBoard(4, 2, []hex.Cell{
+		hex.Cell{X: 0, Y: 0},
+		hex.Cell{X: 0, Y: 2}, hex.Cell{X: 1, Y: 2},
+		hex.Cell{X: 1, Y: 3}})
+	expected = NewBoard(4, 2, []hex.Cell{hex.Cell{X: 0, Y: 1}, hex.Cell{X: 1, Y: 3}})
+	actual, _ = b.ClearFullRows()
+
+	if !equalsBoard(expected, actual) {
+		t.Errorf("Expected cleared board to be: %v, but was: %v",
+			expected, actual)
+	}
+}
+
+func TestDeadCells(t *testing.T) {
+	// the bottom right cell is walled in, the left one is reached from
+	// the side
+	b := NewBoard(3, 3, []hex.Cell{
+		hex.Cell{X: 1, Y: 1}, hex.Cell{X: 2, Y: 1},
+		hex.Cell{X: 1, Y: 2}})
+	actual := b.DeadCells()
+	expected := []hex.Cell{hex.Cell{X: 2, Y: 2}}
+
+	if len(actual) != len(expected) || actual[0] != expected[0] {
+		t.Errorf("Expected dead cells %v, but was: %v\n%v", expected, actual, b)
+	}
+
+	if actual := NewBoard(3, 3, []hex.Cell{}).DeadCells(); len(actual) != 0 {
+		t.Errorf("Expected no dead cells on an empty board, but was: %v", actual)
+	}
+}
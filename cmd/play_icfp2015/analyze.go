@@ -0,0 +1,48 @@
+package main
+
+import "encoding/json"
+import "flag"
+import "fmt"
+import "os"
+import "sort"
+
+import "github.com/mneise/icfp15/analyze"
+import "github.com/mneise/icfp15/game"
+
+func analyzeMain(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	var dir = fs.String("dir", ".", "directory with the pN.json problem files")
+	var problems = fs.String("problems", "", "comma separated problem ids, all by default")
+	var asJSON = fs.Bool("json", false, "print the reports as json")
+	fs.Parse(args)
+
+	all := game.LoadProblems(*dir)
+	ids := []int{}
+	if *problems == "" {
+		for id := range all {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+	} else {
+		ids = parseProblemIds(*problems)
+	}
+
+	rs := []analyze.Report{}
+	for _, id := range ids {
+		p, ok := all[id]
+		if !ok {
+			panic(fmt.Sprintf("unknown problem %v", id))
+		}
+		rs = append(rs, analyze.Analyze(p))
+	}
+
+	if *asJSON {
+		o, err := json.Marshal(&rs)
+		if err != nil {
+			panic(fmt.Sprintf("can't marshal to json: %v", err))
+		}
+		fmt.Println(string(o))
+		return
+	}
+	analyze.Write(os.Stdout, rs)
+}
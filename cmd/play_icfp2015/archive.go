@@ -0,0 +1,59 @@
+package main
+
+import "encoding/json"
+import "flag"
+import "fmt"
+import "os"
+
+import "github.com/mneise/icfp15/archive"
+import "github.com/mneise/icfp15/game"
+
+func archiveMain(args []string) {
+	fs := flag.NewFlagSet("archive", flag.ExitOnError)
+	var dir = fs.String("archive", "solutions", "archive directory")
+	var problems = fs.String("dir", ".", "directory with the pN.json problem files")
+	var settings = fs.String("settings", "", "solver settings to record, defaults to the solution tag")
+	var best = fs.Int("best", -1, "only print the best solutions of this problem")
+	fs.Parse(args)
+
+	a := archive.Archive{Dir: *dir}
+	outs := []game.Output{}
+	if *best >= 0 {
+		outs = a.BestOutputs(*best)
+	} else {
+		in, err := game.ReadOutputs(os.Stdin)
+		if err != nil {
+			panic(fmt.Sprintf("can't read solutions from stdin: %v", err))
+		}
+
+		ps := game.LoadProblems(*problems)
+		for _, o := range in {
+			p, ok := ps[o.ProblemId]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "problem %v seed %v: unknown problem\n", o.ProblemId, o.Seed)
+				continue
+			}
+			s := *settings
+			if s == "" {
+				s = o.Tag
+			}
+			e, improved, err := a.Add(p, o, s)
+			switch {
+			case err != nil:
+				fmt.Fprintf(os.Stderr, "problem %v seed %v: invalid solution: %v\n", o.ProblemId, o.Seed, err)
+			case improved:
+				fmt.Fprintf(os.Stderr, "problem %v seed %v: improved to %v\n", o.ProblemId, o.Seed, e.Score)
+			default:
+				fmt.Fprintf(os.Stderr, "problem %v seed %v: %v is no improvement\n", o.ProblemId, o.Seed, e.Score)
+			}
+		}
+		// improvements, and those a failed submit left behind
+		outs = a.Unsubmitted()
+	}
+
+	o, err := json.Marshal(&outs)
+	if err != nil {
+		panic(fmt.Sprintf("can't marshal to json: %v", err))
+	}
+	fmt.Println(string(o))
+}
@@ -0,0 +1,122 @@
+package main
+
+import "encoding/json"
+import "flag"
+import "fmt"
+import "io/ioutil"
+import "os"
+import "runtime"
+import "sort"
+import "strconv"
+import "strings"
+
+import "github.com/mneise/icfp15/bench"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/solver"
+
+func parseProblemIds(s string) []int {
+	ids := []int{}
+	for _, f := range strings.Split(s, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			panic(fmt.Sprintf("bad problem id %v", f))
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// unitSource makes the sources the bench -source flag names.
+func unitSource(name string) bench.Source {
+	switch name {
+	case "contest":
+		return nil
+	case "random":
+		return func(p game.Program, seed int) game.UnitSource {
+			return game.NewRandomSource(int64(seed), p.SourceLength, len(p.Units))
+		}
+	}
+	return func(p game.Program, seed int) game.UnitSource {
+		s, err := game.ReadFixedSource(name, len(p.Units))
+		if err != nil {
+			panic(fmt.Sprintf("can't read unit source %v: %v", name, err))
+		}
+		return s
+	}
+}
+
+func benchMain(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	var dir = fs.String("dir", ".", "directory with the pN.json problem files")
+	var problems = fs.String("problems", "", "comma separated problem ids, all by default")
+	var jobs = fs.Int("j", runtime.NumCPU(), "number of problems solved in parallel")
+	var save = fs.String("save", "", "save the report as json to this file")
+	var compare = fs.String("compare", "", "compare against a report saved earlier")
+	var solverName = fs.String("solver", "greedy", fmt.Sprintf("solver to use, one of %v", solver.Names()))
+	var weights = fs.String("weights", "", "json file with evaluator weights, as written by tune")
+	var lookahead = fs.Int("lookahead", 0, "number of units the solver sees at a time, the current one included, all by default")
+	var source = fs.String("source", "contest", "unit source: contest, random, or a json file with a list of unit indexes")
+	fs.Parse(args)
+
+	all := game.LoadProblems(*dir)
+	ids := []int{}
+	if *problems == "" {
+		for id := range all {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+	} else {
+		ids = parseProblemIds(*problems)
+	}
+
+	ps := []game.Program{}
+	for _, id := range ids {
+		p, ok := all[id]
+		if !ok {
+			panic(fmt.Sprintf("unknown problem %v", id))
+		}
+		ps = append(ps, p)
+	}
+
+	old := []bench.Row{}
+	if *compare != "" {
+		in, err := ioutil.ReadFile(*compare)
+		if err != nil {
+			panic(fmt.Sprintf("can't open file %v", *compare))
+		}
+		if err := json.Unmarshal(in, &old); err != nil {
+			panic(fmt.Sprintf("can't read report %v: %v", *compare, err))
+		}
+	}
+
+	w := solver.Weights{}
+	if *weights != "" {
+		rw, err := solver.ReadWeights(*weights)
+		if err != nil {
+			panic(fmt.Sprintf("can't read weights %v: %v", *weights, err))
+		}
+		w = rw
+	}
+
+	s, err := solver.New(*solverName, solver.Options{Weights: w, Lookahead: *lookahead})
+	if err != nil {
+		panic(err.Error())
+	}
+
+	rows := bench.RunSource(ps, s, *jobs, unitSource(*source))
+	regressions := bench.WriteReport(os.Stdout, rows, old)
+
+	if *save != "" {
+		o, err := json.MarshalIndent(&rows, "", "  ")
+		if err != nil {
+			panic(fmt.Sprintf("can't marshal to json: %v", err))
+		}
+		if err := ioutil.WriteFile(*save, o, 0644); err != nil {
+			panic(fmt.Sprintf("can't write report %v: %v", *save, err))
+		}
+	}
+
+	if regressions > 0 {
+		os.Exit(1)
+	}
+}
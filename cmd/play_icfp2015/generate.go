@@ -0,0 +1,45 @@
+package main
+
+import "encoding/json"
+import "flag"
+import "fmt"
+import "io/ioutil"
+import "math/rand"
+import "time"
+
+import "github.com/mneise/icfp15/game"
+
+func generateMain(args []string) {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	gp := game.GenParams{}
+	fs.IntVar(&gp.Id, "id", 100, "problem id")
+	fs.IntVar(&gp.Width, "width", 10, "board width")
+	fs.IntVar(&gp.Height, "height", 15, "board height")
+	fs.Float64Var(&gp.Density, "density", 0.2, "fraction of cells filled below the top third")
+	fs.IntVar(&gp.Units, "units", 7, "number of units")
+	fs.IntVar(&gp.MinUnitSize, "min-size", 1, "minimum number of members per unit")
+	fs.IntVar(&gp.MaxUnitSize, "max-size", 6, "maximum number of members per unit")
+	fs.Float64Var(&gp.PivotOutside, "pivot-outside", 0.2, "probability of a pivot outside of its unit")
+	fs.IntVar(&gp.Seeds, "seeds", 5, "number of source seeds")
+	fs.IntVar(&gp.SourceLength, "length", 100, "number of units per game")
+	var seed = fs.Int64("rand", time.Now().UnixNano(), "seed of the generator")
+	var out = fs.String("o", "", "output file, stdout by default")
+	fs.Parse(args)
+
+	p, err := game.GenerateProgram(rand.New(rand.NewSource(*seed)), gp)
+	if err != nil {
+		panic(fmt.Sprintf("can't generate a program: %v", err))
+	}
+	o, err := json.Marshal(&p)
+	if err != nil {
+		panic(fmt.Sprintf("can't marshal to json: %v", err))
+	}
+
+	if *out == "" {
+		fmt.Println(string(o))
+		return
+	}
+	if err := ioutil.WriteFile(*out, o, 0644); err != nil {
+		panic(fmt.Sprintf("can't write file %v: %v", *out, err))
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import "flag"
+import "fmt"
+import "io/ioutil"
+import "encoding/json"
+import "os"
+import "strings"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/config"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/phrases"
+import "github.com/mneise/icfp15/solver"
+
+// revision is the git revision the binary is built from, set by the
+// Makefile with -ldflags "-X main.revision=...".
+var revision = "dev"
+
+func logBoard(p Params, m string, b board.Board) {
+	if p.Config.Debug {
+		fmt.Printf("%v:\n%v\n", m, b)
+	}
+}
+
+func logScore(p Params, m string) {
+	if p.ShowScores || p.Config.Debug {
+		fmt.Printf("%v\n", m)
+	}
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "submit":
+			submitMain(os.Args[2:])
+			return
+		case "serve":
+			serveMain(os.Args[2:])
+			return
+		case "archive":
+			archiveMain(os.Args[2:])
+			return
+		case "bench":
+			benchMain(os.Args[2:])
+			return
+		case "generate":
+			generateMain(os.Args[2:])
+			return
+		case "tune":
+			tuneMain(os.Args[2:])
+			return
+		case "probe":
+			probeMain(os.Args[2:])
+			return
+		case "analyze":
+			analyzeMain(os.Args[2:])
+			return
+		}
+	}
+
+	params := ParseArgs()
+
+	outs := make([]game.Output, len(params.Program.SourceSeeds))
+	totalScore := 0
+
+	if params.LogBoard {
+		b := board.NewBoard(params.Program.Height, params.Program.Width, params.Program.Filled)
+		logBoard(params, fmt.Sprintf("Board for problem: %v", params.Program.Id), b)
+		return
+	}
+
+	s, err := solver.New(params.Config.Solver, params.Config.Options())
+	if err != nil {
+		panic(err.Error())
+	}
+	if params.Config.Portfolio != nil {
+		s = portfolioSolver(params)
+	}
+
+	ps, err := params.Config.PhraseTable()
+	if err != nil {
+		panic(fmt.Sprintf("bad phrase of power: %v", err))
+	}
+	// the solutions are scored with the phrases in effect, as the solver
+	// scores them
+	scored := ps
+	if scored == nil {
+		scored = phrases.PowerPhrases
+	}
+	budget := params.Config.Budget(params.TimeLimitSeconds, len(outs))
+	fmt.Fprintf(os.Stderr, "config %v: %v\n", params.Config.Hash(), params.Config)
+
+	for i, seed := range params.Program.SourceSeeds {
+		sol := s.Solve(solver.Input{Program: params.Program, Seed: seed, Phrases: ps, Budget: budget})
+		r := game.SimulateWith(params.Program, seed, sol.Commands, scored)
+		score := sol.Stats.MoveScore + sol.Stats.PowerScore
+		logScore(params, fmt.Sprintf("%v (move score) + %v (power score) = %v, %v after %v units, %v dead cells\n",
+			sol.Stats.MoveScore, sol.Stats.PowerScore, score, sol.Stats.End, sol.Stats.Placed, r.Dead))
+		totalScore += score
+
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "problem %v seed %v: invalid solution: %v\n", params.Program.Id, seed, r.Err)
+		}
+		tag, err := params.Config.NewTag(revision, params.Program.Id, seed, r.Score).Format(params.Tag)
+		if err != nil {
+			panic(fmt.Sprintf("bad tag template %v: %v", params.Tag, err))
+		}
+
+		outs[i] = game.Output{
+			ProblemId: params.Program.Id,
+			Seed:      seed,
+			Tag:       tag,
+			Solution:  sol.Commands,
+		}
+	}
+
+	logScore(params, fmt.Sprintf("============ Total score for problem %v: %v ============\n", params.Program.Id, totalScore/len(outs)))
+
+	o, err := json.Marshal(&outs)
+	if err != nil {
+		panic(fmt.Sprintf("can't marshal to json: %v", err))
+	}
+	if !params.ShowScores {
+		fmt.Println(string(o))
+	}
+}
+
+// portfolioSolver runs the configured portfolio, telling on stderr which
+// config won every seed.
+func portfolioSolver(params Params) solver.Solver {
+	p := solver.Portfolio{Configs: params.Config.PortfolioConfigs(), Debug: params.Config.Debug}
+	return solver.SolverFunc(func(in solver.Input) solver.Solution {
+		sol, c := p.Solve(in)
+		fmt.Fprintf(os.Stderr, "problem %v seed %v: %v won with %v\n",
+			in.Program.Id, in.Seed, c, sol.Stats.MoveScore+sol.Stats.PowerScore)
+		return sol
+	})
+}
+
+// phraseList collects every -p, as the contest passes one per phrase.
+type phraseList []string
+
+func (l *phraseList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *phraseList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+type Params struct {
+	Program              game.Program
+	TimeLimitSeconds     int
+	MemoryLimitMegaBytes int
+	Cores                int
+	LogBoard             bool
+	ShowScores           bool
+	Tag                  string
+	Config               config.Config
+}
+
+// ParseArgs reads the -config file, if any, and overrides its settings
+// with the flags given on the command line.
+func ParseArgs() Params {
+	var f = flag.String("f", "", "input file name")
+	var t = flag.Int("t", 0, "time limit in seconds")
+	var m = flag.Int("m", 0, "memory limit in megabytes")
+	var c = flag.Int("c", 0, "number of cores available")
+	var p phraseList
+	flag.Var(&p, "p", "phrase of power, may be given several times")
+	var d = flag.Bool("d", false, "print debug output")
+	var b = flag.Bool("b", false, "print start board only")
+	var s = flag.Bool("s", false, "show scores")
+	var configFile = flag.String("config", "", "json file with the solver settings, see config.Config")
+	var solverName = flag.String("solver", config.Default.Solver, fmt.Sprintf("solver to use, one of %v", solver.Names()))
+	var weights = flag.String("weights", "", "json file with evaluator weights, as written by tune")
+	var width = flag.Int("width", 0, "beam width")
+	var units = flag.Int("units", 0, "number of units to place per seed, all by default")
+	var power = flag.Float64("power", 0, "weight of phrases against lines for the power solver")
+	var lookahead = flag.Int("lookahead", 0, "number of units the solver sees at a time, the current one included, all by default")
+	var share = flag.Float64("share", config.Default.TimeShare, "part of the time limit to spend solving")
+	var tag = flag.String("tag", config.DefaultTag, "template of the solution tags, see config.Tag")
+	var portfolio = flag.String("portfolio", "", "json file with solver configs to run per seed, keeping the best")
+
+	flag.Parse()
+
+	in, err := ioutil.ReadFile(*f)
+	if err != nil {
+		panic(fmt.Sprintf("can't open file %v", f))
+	}
+
+	cfg := config.Default
+	if *configFile != "" {
+		if cfg, err = config.Read(*configFile); err != nil {
+			panic(fmt.Sprintf("can't read config %v: %v", *configFile, err))
+		}
+	}
+
+	flag.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "p":
+			cfg.Phrases = p
+		case "d":
+			cfg.Debug = *d
+		case "solver":
+			cfg.Solver = *solverName
+		case "weights":
+			if cfg.Weights, err = solver.ReadWeights(*weights); err != nil {
+				panic(fmt.Sprintf("can't read weights %v: %v", *weights, err))
+			}
+		case "width":
+			cfg.BeamWidth = *width
+		case "units":
+			cfg.MaxUnits = *units
+		case "power":
+			cfg.Power = *power
+		case "lookahead":
+			cfg.Lookahead = *lookahead
+		case "share":
+			cfg.TimeShare = *share
+		case "portfolio":
+			pin, err := ioutil.ReadFile(*portfolio)
+			if err != nil {
+				panic(fmt.Sprintf("can't open file %v", *portfolio))
+			}
+			if err := json.Unmarshal(pin, &cfg.Portfolio); err != nil {
+				panic(fmt.Sprintf("can't read portfolio %v: %v", *portfolio, err))
+			}
+		}
+	})
+
+	return Params{
+		Program:              *game.ReadProgram(in),
+		TimeLimitSeconds:     *t,
+		MemoryLimitMegaBytes: *m,
+		Cores:                *c,
+		LogBoard:             *b,
+		ShowScores:           *s,
+		Tag:                  *tag,
+		Config:               cfg,
+	}
+}
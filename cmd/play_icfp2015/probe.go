@@ -0,0 +1,81 @@
+package main
+
+import "encoding/json"
+import "flag"
+import "fmt"
+import "io/ioutil"
+import "net/http"
+import "os"
+
+import "github.com/mneise/icfp15/contest"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/phrases"
+import "github.com/mneise/icfp15/probe"
+
+func probeMain(args []string) {
+	fs := flag.NewFlagSet("probe", flag.ExitOnError)
+	var f = fs.String("f", "p2.json", "problem to spell the candidates on")
+	var known phraseList
+	fs.Var(&known, "known", "phrase of power known to be real, may be given several times, ours by default")
+	var submit = fs.Bool("submit", false, "submit the probes one by one and tell which phrases are real")
+	var conf = fs.String("config", "", "json config file with baseUrl, teamId and token")
+	var u = fs.String("url", "", "base url of the contest server")
+	var team = fs.Int("team", 0, "team id")
+	fs.Parse(args)
+
+	in, err := ioutil.ReadFile(*f)
+	if err != nil {
+		panic(fmt.Sprintf("can't open file %v", *f))
+	}
+	p := *game.ReadProgram(in)
+	if len(known) == 0 {
+		for _, ph := range phrases.PowerPhrases {
+			known = append(known, ph)
+		}
+	}
+
+	probes := []probe.Probe{}
+	for _, c := range fs.Args() {
+		pr, err := probe.Plan(p, c, known)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%v\n", pr)
+		probes = append(probes, pr)
+	}
+
+	if !*submit {
+		outs := []game.Output{}
+		for _, pr := range probes {
+			outs = append(outs, pr.Output)
+		}
+		o, err := json.Marshal(&outs)
+		if err != nil {
+			panic(fmt.Sprintf("can't marshal to json: %v", err))
+		}
+		fmt.Println(string(o))
+		return
+	}
+
+	c := contest.ReadSubmitConfig(*conf)
+	if *u != "" {
+		c.BaseURL = *u
+	}
+	if *team != 0 {
+		c.TeamId = *team
+	}
+	for _, pr := range probes {
+		score, err := probe.Submit(http.DefaultClient, c, pr)
+		switch {
+		case err != nil:
+			fmt.Printf("%q: failed: %v\n", pr.Phrase, err)
+		case pr.IsReal(score):
+			fmt.Printf("%q: real, scored %v\n", pr.Phrase, score)
+		case score == pr.MoveScore:
+			fmt.Printf("%q: not a phrase, scored %v\n", pr.Phrase, score)
+		default:
+			fmt.Printf("%q: unexpected score %v, expected %v or %v\n", pr.Phrase, score, pr.Expected(), pr.MoveScore)
+		}
+	}
+}
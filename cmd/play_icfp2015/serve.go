@@ -0,0 +1,32 @@
+package main
+
+import "flag"
+import "fmt"
+import "net/http"
+import "os"
+
+import "github.com/mneise/icfp15/contest"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/phrases"
+
+func serveMain(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	var addr = fs.String("addr", "localhost:8015", "address to listen on")
+	var dir = fs.String("dir", ".", "directory with the pN.json problem files")
+	var token = fs.String("token", os.Getenv("ICFP_API_TOKEN"), "api token clients must send")
+	var secret phraseList
+	fs.Var(&secret, "phrase", "phrase of power solutions score, may be given several times, ours by default")
+	fs.Parse(args)
+
+	ps := game.LoadProblems(*dir)
+	s := contest.NewMockServer(*token, ps)
+	if len(secret) > 0 {
+		t, err := phrases.Table(secret)
+		if err != nil {
+			panic(fmt.Sprintf("bad phrase of power: %v", err))
+		}
+		s.Phrases = t
+	}
+	fmt.Printf("serving %v problems on http://%v\n", len(ps), *addr)
+	panic(http.ListenAndServe(*addr, s))
+}
@@ -0,0 +1,80 @@
+package main
+
+import "flag"
+import "fmt"
+import "net/http"
+import "os"
+
+import "github.com/mneise/icfp15/archive"
+import "github.com/mneise/icfp15/contest"
+import "github.com/mneise/icfp15/game"
+
+func submitMain(args []string) {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	var conf = fs.String("config", "", "json config file with baseUrl, teamId and token")
+	var u = fs.String("url", "", "base url of the contest server")
+	var team = fs.Int("team", 0, "team id")
+	var dry = fs.Bool("dry-run", false, "only print what would be submitted")
+	var dir = fs.String("archive", "", "archive directory to mark accepted solutions as submitted in")
+	fs.Parse(args)
+
+	c := contest.ReadSubmitConfig(*conf)
+	if *u != "" {
+		c.BaseURL = *u
+	}
+	if *team != 0 {
+		c.TeamId = *team
+	}
+
+	outs := []game.Output{}
+	if fs.NArg() == 0 {
+		batch, err := game.ReadOutputs(os.Stdin)
+		if err != nil {
+			panic(fmt.Sprintf("can't read solutions from stdin: %v", err))
+		}
+		outs = batch
+	}
+	for _, f := range fs.Args() {
+		in, err := os.Open(f)
+		if err != nil {
+			panic(fmt.Sprintf("can't open file %v", f))
+		}
+		batch, err := game.ReadOutputs(in)
+		in.Close()
+		if err != nil {
+			panic(fmt.Sprintf("can't read solutions from %v: %v", f, err))
+		}
+		outs = append(outs, batch...)
+	}
+
+	if *dry {
+		ids, byId := contest.GroupByProblem(outs)
+		for _, id := range ids {
+			fmt.Printf("problem %v: would submit %v solutions to %v\n", id, len(byId[id]), c.SolutionsURL())
+		}
+		return
+	}
+
+	if c.Token == "" {
+		panic("no api token, set ICFP_API_TOKEN or use -config")
+	}
+
+	a := archive.Archive{Dir: *dir}
+	_, byId := contest.GroupByProblem(outs)
+	failed := false
+	for _, r := range contest.Submit(http.DefaultClient, c, outs) {
+		fmt.Println(r)
+		failed = failed || r.Err != nil
+		if r.Err != nil || *dir == "" {
+			continue
+		}
+		for _, o := range byId[r.ProblemId] {
+			if err := a.MarkSubmitted(o); err != nil {
+				fmt.Fprintf(os.Stderr, "problem %v seed %v: can't mark as submitted: %v\n", o.ProblemId, o.Seed, err)
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import "encoding/json"
+import "flag"
+import "fmt"
+import "io/ioutil"
+import "math/rand"
+import "os"
+import "runtime"
+import "sort"
+import "time"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/solver"
+import "github.com/mneise/icfp15/tune"
+
+func tuneMain(args []string) {
+	fs := flag.NewFlagSet("tune", flag.ExitOnError)
+	var dir = fs.String("dir", ".", "directory with the pN.json problem files")
+	var problems = fs.String("problems", "", "comma separated problem ids to train on, all by default")
+	var seeds = fs.Int("seeds", 2, "number of seeds per problem to train on")
+	var start = fs.String("weights", "", "weights to start from, the defaults otherwise")
+	var out = fs.String("o", "weights.json", "file to write the best weights to")
+	var seed = fs.Int64("rand", time.Now().UnixNano(), "seed of the search")
+	p := tune.Params{}
+	fs.StringVar(&p.Solver, "solver", "heuristic", fmt.Sprintf("solver to tune, one of %v", solver.Names()))
+	fs.IntVar(&p.BeamWidth, "width", 0, "beam width of the solver")
+	fs.IntVar(&p.Population, "pop", 12, "population size")
+	fs.IntVar(&p.Generations, "gen", 10, "number of generations")
+	fs.Float64Var(&p.Sigma, "sigma", 0.3, "relative standard deviation of mutations")
+	fs.IntVar(&p.Jobs, "j", runtime.NumCPU(), "number of games played in parallel")
+	fs.Parse(args)
+
+	all := game.LoadProblems(*dir)
+	ids := []int{}
+	if *problems == "" {
+		for id := range all {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+	} else {
+		ids = parseProblemIds(*problems)
+	}
+
+	samples := []tune.Sample{}
+	for _, id := range ids {
+		pr, ok := all[id]
+		if !ok {
+			panic(fmt.Sprintf("unknown problem %v", id))
+		}
+		for i, s := range pr.SourceSeeds {
+			if i < *seeds {
+				samples = append(samples, tune.Sample{Program: pr, Seed: s})
+			}
+		}
+	}
+
+	w := solver.DefaultWeights
+	if *start != "" {
+		sw, err := solver.ReadWeights(*start)
+		if err != nil {
+			panic(fmt.Sprintf("can't read weights %v: %v", *start, err))
+		}
+		w = sw
+	}
+
+	fmt.Fprintf(os.Stderr, "tuning %v on %v samples, starting from %+v scoring %v\n",
+		p.Solver, len(samples), w, tune.Fitness(samples, p, w))
+	best := tune.Evolve(rand.New(rand.NewSource(*seed)), samples, p, w, func(gen int, b tune.Individual) {
+		fmt.Fprintf(os.Stderr, "generation %v: %v %+v\n", gen, b.Score, b.Weights)
+	})
+	fmt.Fprintf(os.Stderr, "best: %v %+v\n", best.Score, best.Weights)
+
+	o, err := json.MarshalIndent(&best.Weights, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("can't marshal to json: %v", err))
+	}
+	if err := ioutil.WriteFile(*out, o, 0644); err != nil {
+		panic(fmt.Sprintf("can't write file %v: %v", *out, err))
+	}
+}
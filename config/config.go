@@ -0,0 +1,97 @@
+// Package config holds the settings a run of the solver comes from, read
+// from a json file and overridden by command line flags.
+package config
+
+import "encoding/json"
+import "io/ioutil"
+import "time"
+
+import "github.com/mneise/icfp15/phrases"
+import "github.com/mneise/icfp15/solver"
+
+// Config is everything that decides which solution a run produces.
+type Config struct {
+	Solver    string         `json:"solver"`
+	Weights   solver.Weights `json:"weights"`
+	BeamWidth int            `json:"beamWidth,omitempty"`
+	// MaxUnits is the number of units placed per seed, zero means all.
+	MaxUnits int `json:"maxUnits,omitempty"`
+	// Power weighs phrases against lines for the power solver.
+	Power float64 `json:"power,omitempty"`
+	// Lookahead is the number of units the solver sees at a time, the
+	// current one included, zero means all.
+	Lookahead int `json:"lookahead,omitempty"`
+	// Phrases are the phrases of power to spell, none means
+	// phrases.PowerPhrases.
+	Phrases []string `json:"phrases,omitempty"`
+	// TimeShare is the part of the time limit spent solving, split
+	// evenly among the seeds.
+	TimeShare float64 `json:"timeShare"`
+	// Portfolio are the solvers to run per seed, keeping the best. MaxUnits,
+	// Power and Lookahead apply to those that don't set their own.
+	Portfolio []solver.Config `json:"portfolio,omitempty"`
+	Debug     bool            `json:"debug,omitempty"`
+}
+
+var Default = Config{Solver: "greedy", TimeShare: 0.9}
+
+// Read reads a config file on top of Default, so it only needs to list
+// the settings it changes.
+func Read(path string) (Config, error) {
+	c := Default
+	in, err := ioutil.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(in, &c)
+	return c, err
+}
+
+// String is the config as compact json, leaving out what isn't set.
+func (c Config) String() string {
+	o, err := json.Marshal(&c)
+	if err != nil {
+		return err.Error()
+	}
+	return string(o)
+}
+
+func (c Config) Options() solver.Options {
+	return solver.Options{Debug: c.Debug, Weights: c.Weights, BeamWidth: c.BeamWidth, MaxUnits: c.MaxUnits, Power: c.Power, Lookahead: c.Lookahead}
+}
+
+// PortfolioConfigs are the portfolio's configs with the settings they
+// leave out taken from c.
+func (c Config) PortfolioConfigs() []solver.Config {
+	cs := []solver.Config{}
+	for _, pc := range c.Portfolio {
+		if pc.MaxUnits == 0 {
+			pc.MaxUnits = c.MaxUnits
+		}
+		if pc.Power == 0 {
+			pc.Power = c.Power
+		}
+		if pc.Lookahead == 0 {
+			pc.Lookahead = c.Lookahead
+		}
+		cs = append(cs, pc)
+	}
+	return cs
+}
+
+// PhraseTable keys the phrases by their spelling, nil if there are none.
+func (c Config) PhraseTable() (map[string]string, error) {
+	if len(c.Phrases) == 0 {
+		return nil, nil
+	}
+	return phrases.Table(c.Phrases)
+}
+
+// Budget is the time each of seeds may take within a limit of seconds,
+// zero if there is no limit.
+func (c Config) Budget(seconds, seeds int) time.Duration {
+	if seconds <= 0 || seeds <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Duration(seconds)*time.Second) * c.TimeShare / float64(seeds))
+}
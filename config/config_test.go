@@ -0,0 +1,70 @@
+package config
+
+import "io/ioutil"
+import "os"
+import "path/filepath"
+import "testing"
+import "time"
+
+import "github.com/mneise/icfp15/solver"
+
+func TestRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	in := `{"solver": "beam", "beamWidth": 6, "phrases": ["Ei!", "ia! ia!"], "weights": {"lines": 10}}`
+	if err := ioutil.WriteFile(path, []byte(in), 0644); err != nil {
+		t.Fatalf("can't write config: %v", err)
+	}
+
+	c, err := Read(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.Solver != "beam" || c.BeamWidth != 6 || c.Weights.Lines != 10 || c.TimeShare != Default.TimeShare {
+		t.Errorf("Expected settings from file on top of the defaults, got %v", c)
+	}
+
+	ps, err := c.PhraseTable()
+	if err != nil || ps["bap"] != "ei!" || ps["aaplaap"] != "ia! ia!" {
+		t.Errorf("Expected phrase table for %v, got %v %v", c.Phrases, ps, err)
+	}
+
+	rc, err := Read(path)
+	if err != nil || rc.String() != c.String() {
+		t.Errorf("Expected the same config reading it again, got %v", rc)
+	}
+
+	if _, err := Read(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+}
+
+func TestBudget(t *testing.T) {
+	c := Default
+	if b := c.Budget(0, 10); b != 0 {
+		t.Errorf("Expected no budget without a time limit, got %v", b)
+	}
+	if b := c.Budget(10, 3); b != 3*time.Second {
+		t.Errorf("Expected 3s budget, got %v", b)
+	}
+}
+
+func TestPortfolioConfigs(t *testing.T) {
+	c := Default
+	c.Lookahead = 2
+	c.Power = 0.5
+	c.Portfolio = []solver.Config{
+		solver.Config{Solver: "greedy"},
+		solver.Config{Solver: "power", Power: 2},
+	}
+	actual := c.PortfolioConfigs()
+	if len(actual) != 2 || actual[0].Lookahead != 2 || actual[0].Power != 0.5 ||
+		actual[1].Lookahead != 2 || actual[1].Power != 2 {
+		t.Errorf("Expected the configs to default to the config's settings, got: %v", actual)
+	}
+}
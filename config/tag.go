@@ -0,0 +1,52 @@
+package config
+
+import "bytes"
+import "crypto/sha1"
+import "fmt"
+import "text/template"
+
+// DefaultTag is the tag template used unless one is given, e.g.
+// "beam-3f2a9c1e@1a2b3c4:1164".
+const DefaultTag = "{{.Solver}}-{{.Hash}}@{{.Revision}}:{{.Score}}"
+
+// Hash is a short hash of the config, telling runs with different
+// settings apart. Debug output doesn't change the solution, so it's left
+// out.
+func (c Config) Hash() string {
+	c.Debug = false
+	return fmt.Sprintf("%x", sha1.Sum([]byte(c.String())))[:8]
+}
+
+// Tag is what a tag template may refer to.
+type Tag struct {
+	Solver   string
+	Hash     string
+	Revision string
+	Problem  int
+	Seed     int
+	// Score is the score game.Simulate gives the solution.
+	Score int
+}
+
+// NewTag fills in the solver and hash of the config, a portfolio is
+// named after itself as it runs several solvers.
+func (c Config) NewTag(revision string, problem, seed, score int) Tag {
+	s := c.Solver
+	if c.Portfolio != nil {
+		s = "portfolio"
+	}
+	return Tag{Solver: s, Hash: c.Hash(), Revision: revision, Problem: problem, Seed: seed, Score: score}
+}
+
+// Format executes the template text on the tag.
+func (t Tag) Format(text string) (string, error) {
+	tmpl, err := template.New("tag").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, t); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
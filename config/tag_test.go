@@ -0,0 +1,46 @@
+package config
+
+import "testing"
+
+import "github.com/mneise/icfp15/solver"
+
+func TestHash(t *testing.T) {
+	c := Default
+	if c.Hash() != Default.Hash() || len(c.Hash()) != 8 {
+		t.Errorf("Expected the same short hash for the same config, got %v", c.Hash())
+	}
+	c.Debug = true
+	if c.Hash() != Default.Hash() {
+		t.Errorf("Expected debugging to keep the hash, got %v", c.Hash())
+	}
+	c.BeamWidth = 2
+	if c.Hash() == Default.Hash() {
+		t.Errorf("Expected a different hash for a different config")
+	}
+}
+
+func TestTagFormat(t *testing.T) {
+	c := Default
+	c.Solver = "beam"
+	tag := c.NewTag("1a2b3c4", 3, 42, 1164)
+
+	actual, err := tag.Format(DefaultTag)
+	expected := "beam-" + c.Hash() + "@1a2b3c4:1164"
+	if err != nil || actual != expected {
+		t.Errorf("Expected tag %v, but was: %v %v", expected, actual, err)
+	}
+
+	actual, err = tag.Format("p{{.Problem}}s{{.Seed}}")
+	if err != nil || actual != "p3s42" {
+		t.Errorf("Expected tag p3s42, but was: %v %v", actual, err)
+	}
+
+	c.Portfolio = []solver.Config{}
+	if s := c.NewTag("", 0, 0, 0).Solver; s != "portfolio" {
+		t.Errorf("Expected a portfolio to be tagged as one, but was: %v", s)
+	}
+
+	if _, err := tag.Format("{{.Nope}}"); err == nil {
+		t.Errorf("Expected an error for an unknown field")
+	}
+}
@@ -0,0 +1,172 @@
+package contest
+
+import "encoding/json"
+import "fmt"
+import "net/http"
+import "sort"
+import "strconv"
+import "strings"
+import "sync"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/phrases"
+
+// MockServer stands in for the contest server. It accepts solutions under
+// /teams/{id}/solutions, scores them with game.Simulate and keeps the best score
+// per team, problem and seed for the /leaderboard.
+type MockServer struct {
+	Token    string
+	Problems map[int]game.Program
+	// Phrases are the phrases of power solutions score, keyed by spelling
+	// as in phrases.PowerPhrases. Nil means phrases.PowerPhrases, set
+	// others to try finding phrases against the server.
+	Phrases map[string]string
+
+	mu   sync.Mutex
+	best map[int]map[int]map[int]int
+}
+
+type ScoredOutput struct {
+	ProblemId  int    `json:"problemId"`
+	Seed       int    `json:"seed"`
+	Score      int    `json:"score"`
+	PowerScore int    `json:"powerScore"`
+	Error      string `json:"error,omitempty"`
+}
+
+type ProblemScore struct {
+	ProblemId int `json:"problemId"`
+	Score     int `json:"score"`
+}
+
+type TeamScore struct {
+	TeamId   int            `json:"teamId"`
+	Total    int            `json:"total"`
+	Problems []ProblemScore `json:"problems"`
+}
+
+func NewMockServer(token string, problems map[int]game.Program) *MockServer {
+	return &MockServer{
+		Token:    token,
+		Problems: problems,
+		best:     map[int]map[int]map[int]int{},
+	}
+}
+
+func (s *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ps := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(ps) == 1 && ps[0] == "leaderboard" && r.Method == "GET":
+		s.writeJSON(w, s.Leaderboard())
+	case len(ps) == 3 && ps[0] == "teams" && ps[2] == "solutions" && r.Method == "POST":
+		team, err := strconv.Atoi(ps[1])
+		if err != nil {
+			http.Error(w, fmt.Sprintf("bad team id %v", ps[1]), http.StatusNotFound)
+			return
+		}
+		if _, token, ok := r.BasicAuth(); !ok || token != s.Token {
+			w.Header().Set("WWW-Authenticate", `Basic realm="icfp"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		outs := []game.Output{}
+		if err := json.NewDecoder(r.Body).Decode(&outs); err != nil {
+			http.Error(w, fmt.Sprintf("can't read solutions: %v", err), http.StatusBadRequest)
+			return
+		}
+		s.writeJSON(w, s.Score(team, outs))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *MockServer) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func hasSeed(p game.Program, seed int) bool {
+	for _, s := range p.SourceSeeds {
+		if s == seed {
+			return true
+		}
+	}
+	return false
+}
+
+// Score simulates every solution and records improvements for the team.
+func (s *MockServer) Score(team int, outs []game.Output) []ScoredOutput {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scored := []ScoredOutput{}
+	for _, o := range outs {
+		so := ScoredOutput{ProblemId: o.ProblemId, Seed: o.Seed}
+		p, ok := s.Problems[o.ProblemId]
+		switch {
+		case !ok:
+			so.Error = fmt.Sprintf("unknown problem %v", o.ProblemId)
+		case !hasSeed(p, o.Seed):
+			so.Error = fmt.Sprintf("unknown seed %v for problem %v", o.Seed, o.ProblemId)
+		default:
+			ps := s.Phrases
+			if ps == nil {
+				ps = phrases.PowerPhrases
+			}
+			r := game.SimulateWith(p, o.Seed, o.Solution, ps)
+			so.Score = r.Score
+			so.PowerScore = r.PowerScore
+			if r.Err != nil {
+				so.Error = r.Err.Error()
+				so.Score = 0
+				so.PowerScore = 0
+			}
+			s.record(team, o.ProblemId, o.Seed, so.Score)
+		}
+		scored = append(scored, so)
+	}
+	return scored
+}
+
+func (s *MockServer) record(team, problem, seed, score int) {
+	if s.best[team] == nil {
+		s.best[team] = map[int]map[int]int{}
+	}
+	if s.best[team][problem] == nil {
+		s.best[team][problem] = map[int]int{}
+	}
+	if old, ok := s.best[team][problem][seed]; !ok || score > old {
+		s.best[team][problem][seed] = score
+	}
+}
+
+// Leaderboard ranks teams by their total over problems, where a problem
+// scores the average of the best solutions over all of its seeds.
+func (s *MockServer) Leaderboard() []TeamScore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts := []TeamScore{}
+	for team, problems := range s.best {
+		t := TeamScore{TeamId: team, Problems: []ProblemScore{}}
+		for id, seeds := range problems {
+			sum := 0
+			for _, score := range seeds {
+				sum += score
+			}
+			ps := ProblemScore{ProblemId: id, Score: sum / len(s.Problems[id].SourceSeeds)}
+			t.Problems = append(t.Problems, ps)
+			t.Total += ps.Score
+		}
+		sort.Slice(t.Problems, func(i, j int) bool {
+			return t.Problems[i].ProblemId < t.Problems[j].ProblemId
+		})
+		ts = append(ts, t)
+	}
+	sort.Slice(ts, func(i, j int) bool {
+		return ts[i].Total > ts[j].Total || (ts[i].Total == ts[j].Total && ts[i].TeamId < ts[j].TeamId)
+	})
+	return ts
+}
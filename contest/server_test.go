@@ -0,0 +1,52 @@
+package contest
+
+import "net/http"
+import "net/http/httptest"
+import "testing"
+
+import "encoding/json"
+import "github.com/mneise/icfp15/game"
+
+func TestMockServerEndToEnd(t *testing.T) {
+	p := game.LoadProblems("../testdata")[90]
+	server := httptest.NewServer(NewMockServer("secret", map[int]game.Program{90: p}))
+	defer server.Close()
+
+	c := SubmitConfig{BaseURL: server.URL, TeamId: 260, Token: "secret"}
+	outs := []game.Output{
+		game.Output{ProblemId: 90, Seed: 0, Solution: "ll"},
+		game.Output{ProblemId: 90, Seed: 1, Solution: "ei!"},
+		game.Output{ProblemId: 91, Seed: 0, Solution: "ll"},
+	}
+	rs := Submit(server.Client(), c, outs)
+	if len(rs) != 2 || rs[0].Err != nil || rs[1].Err != nil {
+		t.Errorf("Expected submissions to be accepted, got: %v", rs)
+	}
+
+	scored := []ScoredOutput{}
+	json.Unmarshal([]byte(rs[0].Body), &scored)
+	if len(scored) != 2 || scored[0].Score != 101 || scored[1].Score != 407 || scored[1].PowerScore != 306 {
+		t.Errorf("Failed to score solutions, got: %v", scored)
+	}
+
+	// worse solutions don't replace the best ones
+	Submit(server.Client(), c, []game.Output{game.Output{ProblemId: 90, Seed: 0, Solution: "bp"}})
+
+	c.Token = "wrong"
+	rs = Submit(server.Client(), c, outs[:1])
+	if rs[0].Status != http.StatusUnauthorized {
+		t.Errorf("Expected wrong token to be rejected, got: %v", rs)
+	}
+
+	resp, err := server.Client().Get(server.URL + "/leaderboard")
+	if err != nil {
+		t.Fatalf("Failed to get leaderboard: %v", err)
+	}
+	defer resp.Body.Close()
+
+	lb := []TeamScore{}
+	json.NewDecoder(resp.Body).Decode(&lb)
+	if len(lb) != 1 || lb[0].TeamId != 260 || lb[0].Total != 254 || len(lb[0].Problems) != 1 {
+		t.Errorf("Unexpected leaderboard: %v", lb)
+	}
+}
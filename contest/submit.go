@@ -0,0 +1,119 @@
+// Package contest talks to the contest server: it submits solutions and
+// provides a local mock of the server for offline testing.
+package contest
+
+import "bytes"
+import "encoding/json"
+import "fmt"
+import "io/ioutil"
+import "net/http"
+import "os"
+import "sort"
+
+import "github.com/mneise/icfp15/game"
+
+const DefaultSubmitURL = "https://davar.icfpcontest.org"
+const DefaultTeamId = 260
+
+// SubmitConfig describes where and as whom solutions are submitted. The
+// token is sent as the basic auth password with an empty user name.
+type SubmitConfig struct {
+	BaseURL string `json:"baseUrl"`
+	TeamId  int    `json:"teamId"`
+	Token   string `json:"token"`
+}
+
+type SubmitResult struct {
+	ProblemId int
+	Count     int
+	Status    int
+	Body      string
+	Err       error
+}
+
+func (r SubmitResult) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("problem %v: %v solutions, failed: %v", r.ProblemId, r.Count, r.Err)
+	}
+	return fmt.Sprintf("problem %v: %v solutions, %v %v", r.ProblemId, r.Count, r.Status, r.Body)
+}
+
+func (c SubmitConfig) SolutionsURL() string {
+	return fmt.Sprintf("%v/teams/%v/solutions", c.BaseURL, c.TeamId)
+}
+
+// GroupByProblem groups outputs by problem id, returning the ids in order.
+func GroupByProblem(outs []game.Output) ([]int, map[int][]game.Output) {
+	ids := []int{}
+	byId := map[int][]game.Output{}
+	for _, o := range outs {
+		if _, ok := byId[o.ProblemId]; !ok {
+			ids = append(ids, o.ProblemId)
+		}
+		byId[o.ProblemId] = append(byId[o.ProblemId], o)
+	}
+	sort.Ints(ids)
+	return ids, byId
+}
+
+// Submit posts the outputs of every problem in a separate request and
+// reports the server's answer per problem.
+func Submit(client *http.Client, c SubmitConfig, outs []game.Output) []SubmitResult {
+	rs := []SubmitResult{}
+	ids, byId := GroupByProblem(outs)
+	for _, id := range ids {
+		r := SubmitResult{ProblemId: id, Count: len(byId[id])}
+		r.Status, r.Body, r.Err = postSolutions(client, c, byId[id])
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+func postSolutions(client *http.Client, c SubmitConfig, outs []game.Output) (int, string, error) {
+	body, err := json.Marshal(&outs)
+	if err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequest("POST", c.SolutionsURL(), bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth("", c.Token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	rb, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, string(rb), fmt.Errorf("unexpected status %v: %s", resp.Status, rb)
+	}
+
+	return resp.StatusCode, string(rb), nil
+}
+
+// ReadSubmitConfig reads the config file, if any, and lets the environment
+// override the token so it never has to live in the repository.
+func ReadSubmitConfig(path string) SubmitConfig {
+	c := SubmitConfig{BaseURL: DefaultSubmitURL, TeamId: DefaultTeamId}
+	if path != "" {
+		in, err := ioutil.ReadFile(path)
+		if err != nil {
+			panic(fmt.Sprintf("can't open config file %v: %v", path, err))
+		}
+		if err := json.Unmarshal(in, &c); err != nil {
+			panic(fmt.Sprintf("can't read config file %v: %v", path, err))
+		}
+	}
+	if t := os.Getenv("ICFP_API_TOKEN"); t != "" {
+		c.Token = t
+	}
+	return c
+}
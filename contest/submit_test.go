@@ -0,0 +1,49 @@
+package contest
+
+import "net/http"
+import "net/http/httptest"
+import "testing"
+
+import "encoding/json"
+import "github.com/mneise/icfp15/game"
+
+func TestSubmit(t *testing.T) {
+	posted := map[string][]game.Output{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, token, ok := r.BasicAuth(); !ok || token != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		outs := []game.Output{}
+		json.NewDecoder(r.Body).Decode(&outs)
+		posted[r.URL.Path] = append(posted[r.URL.Path], outs...)
+		w.Write([]byte("created"))
+	}))
+	defer server.Close()
+
+	outs := []game.Output{
+		game.Output{ProblemId: 3, Seed: 0, Solution: "bap"},
+		game.Output{ProblemId: 1, Seed: 0, Solution: "l"},
+		game.Output{ProblemId: 3, Seed: 5, Solution: "a"},
+	}
+	c := SubmitConfig{BaseURL: server.URL, TeamId: 42, Token: "secret"}
+	actual := Submit(server.Client(), c, outs)
+
+	if len(actual) != 2 || actual[0].ProblemId != 1 || actual[1].ProblemId != 3 || actual[1].Count != 2 {
+		t.Errorf("Expected one result per problem, got: %v", actual)
+	}
+	for _, r := range actual {
+		if r.Err != nil || r.Status != http.StatusOK || r.Body != "created" {
+			t.Errorf("Expected submission to succeed, got: %v", r)
+		}
+	}
+	if len(posted["/teams/42/solutions"]) != 3 {
+		t.Errorf("Expected 3 solutions posted for team 42, got: %v", posted)
+	}
+
+	c.Token = "wrong"
+	actual = Submit(server.Client(), c, outs[:1])
+	if len(actual) != 1 || actual[0].Err == nil || actual[0].Status != http.StatusUnauthorized {
+		t.Errorf("Expected submission to be rejected, got: %v", actual)
+	}
+}
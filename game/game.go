@@ -0,0 +1,101 @@
+// Package game holds the contest's problem and solution formats, the
+// unit source RNG, scoring and a simulator replaying solutions.
+package game
+
+import "encoding/json"
+import "fmt"
+import "io"
+import "io/ioutil"
+import "path/filepath"
+
+import "github.com/mneise/icfp15/hex"
+
+// todo: should we use float64 just cause json
+type Program struct {
+	Id           int        `json:"id"`
+	Units        []hex.Unit `json:"units"`
+	Width        int        `json:"width"`
+	Height       int        `json:"height"`
+	Filled       []hex.Cell `json:"filled"`
+	SourceLength int        `json:"sourceLength"`
+	SourceSeeds  []int      `json:"sourceSeeds"`
+}
+
+// Output is the solution for one seed of a problem, as submitted.
+type Output struct {
+	ProblemId int    `json:"problemId"`
+	Seed      int    `json:"seed"`
+	Tag       string `json:"tag"`
+	Solution  string `json:"solution"`
+}
+
+func ReadProgram(data []byte) *Program {
+	p := &Program{}
+	json.Unmarshal(data, &p)
+
+	return p
+}
+
+// LoadProblems reads all pN.json files in dir, keyed by problem id.
+func LoadProblems(dir string) map[int]Program {
+	ps := map[int]Program{}
+	fs, _ := filepath.Glob(filepath.Join(dir, "p*.json"))
+	for _, f := range fs {
+		in, err := ioutil.ReadFile(f)
+		if err != nil {
+			panic(fmt.Sprintf("can't open file %v", f))
+		}
+		p := ReadProgram(in)
+		ps[p.Id] = *p
+	}
+	return ps
+}
+
+// ReadOutputs reads any number of concatenated Output arrays, as printed by
+// consecutive solver runs.
+func ReadOutputs(r io.Reader) ([]Output, error) {
+	outs := []Output{}
+	dec := json.NewDecoder(r)
+	for {
+		batch := []Output{}
+		err := dec.Decode(&batch)
+		if err == io.EOF {
+			return outs, nil
+		}
+		if err != nil {
+			return outs, err
+		}
+		outs = append(outs, batch...)
+	}
+}
+
+// CalcRandom returns the first l numbers of the contest's linear
+// congruential generator for seed s.
+func CalcRandom(s int, l int) []int {
+	g := NewLCG(s, l, 1)
+	rands := make([]int, l)
+	for i := range rands {
+		rands[i] = g.random()
+	}
+	return rands
+}
+
+// CalcUnitIndexes turns random numbers into indexes of l units.
+func CalcUnitIndexes(rands []int, l int) []int {
+	idxs := make([]int, len(rands))
+	for i, rand := range rands {
+		idxs[i] = rand % l
+	}
+	return idxs
+}
+
+// MoveScore is the score for locking a unit of the given size that clears
+// some rows, with a bonus if the previous unit cleared more than one row.
+func MoveScore(size, cleared, clearedOld int) int {
+	points := size + 100*(1+cleared)*cleared/2
+	lineBonus := 0
+	if clearedOld > 1 {
+		lineBonus = (clearedOld - 1) * points / 10
+	}
+	return points + lineBonus
+}
@@ -0,0 +1,64 @@
+package game
+
+import "strings"
+import "testing"
+
+func TestReadProgram(t *testing.T) {
+	sample := `{"id": 23, "units": [], "width": 5, "height": 5, "filled": [], "sourceLength": 2, "sourceSeeds": []}`
+	actual := ReadProgram([]byte(sample))
+	if actual.Id != 23 || actual.Width != 5 || actual.Height != 5 || actual.SourceLength != 2 {
+		t.Errorf("Failed to read program got: %v", actual)
+	}
+
+	sample = `{"id": 21, "units": [], "width": 10, "height": 5, "filled": [], "sourceLength": 2, "sourceSeeds": []}`
+	actual = ReadProgram([]byte(sample))
+	if actual.Id != 21 || actual.Width != 10 || actual.Height != 5 || actual.SourceLength != 2 {
+		t.Errorf("Failed to read program got: %v", actual)
+	}
+
+	sample = `{"id": 21, "units": [{"members": [{"x": 1, "y": 1}], "pivot": {"x": 1, "y": 1}}], "width": 10, "height": 5, "filled": [{"x": 1, "y": 2}], "sourceLength": 2, "sourceSeeds": []}`
+	actual = ReadProgram([]byte(sample))
+	if len(actual.Units) != 1 || len(actual.Units[0].Members) != 1 || len(actual.Filled) != 1 {
+		t.Errorf("Failed to read program got: %v", actual)
+	}
+
+}
+
+func TestReadOutputs(t *testing.T) {
+	in := `[{"problemId": 1, "seed": 0, "tag": "a", "solution": "bap"}]
+[{"problemId": 2, "seed": 0, "tag": "b", "solution": "l"}, {"problemId": 2, "seed": 7, "tag": "b", "solution": "a"}]`
+	actual, err := ReadOutputs(strings.NewReader(in))
+	if err != nil {
+		t.Errorf("Failed to read outputs: %v", err)
+	}
+
+	if len(actual) != 3 || actual[0].ProblemId != 1 || actual[2].Seed != 7 {
+		t.Errorf("Failed to read outputs got: %v", actual)
+	}
+}
+
+func TestCalcRandom(t *testing.T) {
+	s := 17
+	l := 10
+	expected := []int{0, 24107, 16552, 12125, 9427, 13152, 21440, 3383, 6873, 16117}
+	actual := CalcRandom(s, l)
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("Expected random numbers to be: %v but was: %v", expected, actual)
+		}
+	}
+}
+
+func TestCalcUnitIndexes(t *testing.T) {
+	rands := []int{0, 34, 1000, 3, 76, 93}
+	l := 5
+	expected := []int{0, 4, 0, 3, 1, 3}
+	actual := CalcUnitIndexes(rands, l)
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("Expected unit indexes to be: %v but was: %v", expected, actual)
+		}
+	}
+}
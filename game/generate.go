@@ -0,0 +1,123 @@
+package game
+
+import "fmt"
+import "math/rand"
+
+import "github.com/mneise/icfp15/hex"
+
+// GenParams controls the shape of randomly generated programs.
+type GenParams struct {
+	Id           int
+	Width        int
+	Height       int
+	Density      float64
+	Units        int
+	MinUnitSize  int
+	MaxUnitSize  int
+	PivotOutside float64
+	Seeds        int
+	SourceLength int
+}
+
+// RandomUnit grows a connected unit of the given size from a single cell
+// and moves it to the top left, the way the contest files define units.
+func RandomUnit(r *rand.Rand, size int, pivotOutside float64) hex.Unit {
+	u := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}}
+	for len(u.Members) < size {
+		m := u.Members[r.Intn(len(u.Members))]
+		n := m.Neighbors()[r.Intn(len(hex.Directions))]
+		if !u.Contains(n) {
+			u.Members = append(u.Members, n)
+		}
+	}
+
+	if r.Float64() < pivotOutside {
+		for u.Pivot = u.Members[0]; u.Contains(u.Pivot); {
+			m := u.Members[r.Intn(len(u.Members))]
+			u.Pivot = m.Neighbors()[r.Intn(len(hex.Directions))]
+		}
+	} else {
+		u.Pivot = u.Members[r.Intn(len(u.Members))]
+	}
+
+	minY := u.MinYCell()
+	u = u.MoveTo(minY.ShiftY(-minY.Y), minY)
+	minX := u.MinXCell()
+	return u.MoveTo(hex.Cell{X: 0, Y: minX.Y}, minX)
+}
+
+// validate rejects parameters GenerateProgram can't make a playable
+// program for. A unit is at most as wide and high as it has members, so
+// units no larger than the board's shorter side always fit.
+func (gp GenParams) validate() error {
+	switch {
+	case gp.Width < 1 || gp.Height < 1:
+		return fmt.Errorf("board of %vx%v cells is empty", gp.Width, gp.Height)
+	case gp.Units < 1:
+		return fmt.Errorf("need at least one unit, got %v", gp.Units)
+	case gp.MinUnitSize < 1 || gp.MaxUnitSize < gp.MinUnitSize:
+		return fmt.Errorf("unit sizes from %v to %v are empty", gp.MinUnitSize, gp.MaxUnitSize)
+	case gp.MinUnitSize > gp.Width || gp.MinUnitSize > gp.Height:
+		return fmt.Errorf("units of %v cells may not fit a board of %vx%v cells", gp.MinUnitSize, gp.Width, gp.Height)
+	}
+	return nil
+}
+
+// GenerateProgram returns a program with random units and filled cells.
+// Cells are only filled below the top third of the board and below the
+// tallest unit, so units can always spawn, and rows are never filled
+// completely.
+func GenerateProgram(r *rand.Rand, gp GenParams) (Program, error) {
+	if err := gp.validate(); err != nil {
+		return Program{}, err
+	}
+	p := Program{
+		Id:           gp.Id,
+		Width:        gp.Width,
+		Height:       gp.Height,
+		Units:        []hex.Unit{},
+		Filled:       []hex.Cell{},
+		SourceLength: gp.SourceLength,
+		SourceSeeds:  []int{},
+	}
+
+	maxSize := gp.MaxUnitSize
+	if maxSize > gp.Width {
+		maxSize = gp.Width
+	}
+	if maxSize > gp.Height {
+		maxSize = gp.Height
+	}
+	for len(p.Units) < gp.Units {
+		size := gp.MinUnitSize
+		if maxSize > size {
+			size += r.Intn(maxSize - size + 1)
+		}
+		u := RandomUnit(r, size, gp.PivotOutside)
+		if u.Width() <= gp.Width && u.Height() <= gp.Height {
+			p.Units = append(p.Units, u)
+		}
+	}
+
+	top := gp.Height / 3
+	for _, u := range p.Units {
+		if u.Height() > top {
+			top = u.Height()
+		}
+	}
+	for y := top; y < gp.Height; y++ {
+		filled := 0
+		for x := 0; x < gp.Width; x++ {
+			if filled < gp.Width-1 && r.Float64() < gp.Density {
+				p.Filled = append(p.Filled, hex.Cell{X: x, Y: y})
+				filled++
+			}
+		}
+	}
+
+	for len(p.SourceSeeds) < gp.Seeds {
+		p.SourceSeeds = append(p.SourceSeeds, r.Intn(1<<15))
+	}
+
+	return p, nil
+}
@@ -0,0 +1,110 @@
+package game
+
+import "testing"
+
+import "encoding/json"
+import "math/rand"
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+
+func isConnected(u hex.Unit) bool {
+	seen := map[hex.Cell]bool{u.Members[0]: true}
+	todo := []hex.Cell{u.Members[0]}
+	for len(todo) > 0 {
+		c := todo[0]
+		todo = todo[1:]
+		for _, n := range c.Neighbors() {
+			if u.Contains(n) && !seen[n] {
+				seen[n] = true
+				todo = append(todo, n)
+			}
+		}
+	}
+	return len(seen) == len(u.Members)
+}
+
+func TestGenerateProgram(t *testing.T) {
+	gp := GenParams{
+		Id: 3, Width: 8, Height: 12, Density: 0.3, Units: 10,
+		MinUnitSize: 2, MaxUnitSize: 5, PivotOutside: 0.5, Seeds: 3, SourceLength: 30,
+	}
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20; i++ {
+		p, err := GenerateProgram(r, gp)
+		if err != nil {
+			t.Fatalf("Failed to generate a program: %v", err)
+		}
+		if len(p.Units) != gp.Units || len(p.SourceSeeds) != gp.Seeds {
+			t.Errorf("Wrong number of units or seeds: %v", p)
+		}
+
+		for _, u := range p.Units {
+			if len(u.Members) < gp.MinUnitSize || len(u.Members) > gp.MaxUnitSize || !isConnected(u) {
+				t.Errorf("Invalid unit: %v", u)
+			}
+			if u.MinXCell().X != 0 || u.MinYCell().Y != 0 {
+				t.Errorf("Expected unit in the top left: %v", u)
+			}
+		}
+
+		b := board.NewBoard(p.Height, p.Width, p.Filled)
+		for y := range b {
+			if b.IsRowFull(y) {
+				t.Errorf("Expected no full rows:\n%v", b)
+			}
+		}
+
+		o, _ := json.Marshal(&p)
+		rp := ReadProgram(o)
+		if rp.Width != p.Width || len(rp.Units) != len(p.Units) || len(rp.Filled) != len(p.Filled) {
+			t.Errorf("Failed to read generated program: %s", o)
+		}
+	}
+}
+
+func TestGenerateProgramInvalid(t *testing.T) {
+	valid := GenParams{Width: 4, Height: 6, Units: 2, MinUnitSize: 1, MaxUnitSize: 3, Seeds: 1, SourceLength: 10}
+	r := rand.New(rand.NewSource(1))
+	if _, err := GenerateProgram(r, valid); err != nil {
+		t.Fatalf("Expected %+v to be valid: %v", valid, err)
+	}
+
+	invalid := []func(gp *GenParams){
+		func(gp *GenParams) { gp.Units = 0 },
+		func(gp *GenParams) { gp.Width = 0 },
+		func(gp *GenParams) { gp.MinUnitSize = 0 },
+		func(gp *GenParams) { gp.MaxUnitSize = 0 },
+		func(gp *GenParams) { gp.MinUnitSize, gp.MaxUnitSize = 5, 30 },
+		func(gp *GenParams) { gp.MinUnitSize, gp.MaxUnitSize = 25, 30 },
+	}
+	for _, change := range invalid {
+		gp := valid
+		change(&gp)
+		if _, err := GenerateProgram(r, gp); err == nil {
+			t.Errorf("Expected %+v to be invalid", gp)
+		}
+	}
+}
+
+func TestGenerateProgramSpawns(t *testing.T) {
+	// units up to the board's height, well below its top third
+	gp := GenParams{
+		Width: 8, Height: 8, Density: 0.9, Units: 10,
+		MinUnitSize: 4, MaxUnitSize: 8, Seeds: 1, SourceLength: 10,
+	}
+	r := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 20; i++ {
+		p, err := GenerateProgram(r, gp)
+		if err != nil {
+			t.Fatalf("Failed to generate a program: %v", err)
+		}
+		b := board.NewBoard(p.Height, p.Width, p.Filled)
+		for _, u := range p.Units {
+			if !b.IsValid(b.StartLocation(u)) {
+				t.Errorf("Expected %v to spawn on:\n%v", u, b)
+			}
+		}
+	}
+}
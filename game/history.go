@@ -0,0 +1,131 @@
+package game
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+
+// Step is one unit locked during a game.
+type Step struct {
+	// Unit is the index of the unit in Program.Units.
+	Unit   int
+	Locked hex.Unit
+	// Moves take the unit from its start location to Locked, the last
+	// one locking it.
+	Moves   []hex.Move
+	Cleared int
+	// Score is the move score the step adds.
+	Score int
+	// Board is the board after the step, full rows cleared.
+	Board board.Board
+}
+
+// History records the steps of a game so they can be undone, redone and
+// branched from. Boards are never changed in place, so steps share them.
+type History struct {
+	start board.Board
+	steps []Step
+	// pos is the number of steps in effect, those after it can be redone.
+	pos int
+}
+
+func NewHistory(b board.Board) *History {
+	return &History{start: b}
+}
+
+// Board is the board after the steps in effect.
+func (h *History) Board() board.Board {
+	if h.pos == 0 {
+		return h.start
+	}
+	return h.steps[h.pos-1].Board
+}
+
+// Len is the number of steps in effect, which is also the position of
+// the next unit in the source.
+func (h *History) Len() int {
+	return h.pos
+}
+
+// Steps are the steps in effect.
+func (h *History) Steps() []Step {
+	return h.steps[:h.pos]
+}
+
+func (h *History) cleared() int {
+	if h.pos == 0 {
+		return 0
+	}
+	return h.steps[h.pos-1].Cleared
+}
+
+// Lock locks u, the unit with index unit, after the moves and clears full
+// rows. It drops the steps that could have been redone.
+func (h *History) Lock(unit int, u hex.Unit, moves []hex.Move) Step {
+	b, cleared := h.Board().FillCells(u.Members).ClearFullRows()
+	s := Step{
+		Unit:    unit,
+		Locked:  u,
+		Moves:   moves,
+		Cleared: cleared,
+		Score:   MoveScore(len(u.Members), cleared, h.cleared()),
+		Board:   b,
+	}
+	h.steps = append(h.steps[:h.pos], s)
+	h.pos++
+	return s
+}
+
+// Undo takes back the last step in effect, false if there is none.
+func (h *History) Undo() bool {
+	if h.pos == 0 {
+		return false
+	}
+	h.pos--
+	return true
+}
+
+// Redo puts back the last undone step, false if there is none.
+func (h *History) Redo() bool {
+	if h.pos == len(h.steps) {
+		return false
+	}
+	h.pos++
+	return true
+}
+
+// Branch is a new history with the steps in effect, locking in one
+// leaves the other alone.
+func (h *History) Branch() *History {
+	steps := make([]Step, h.pos)
+	copy(steps, h.steps)
+	return &History{start: h.start, steps: steps, pos: h.pos}
+}
+
+// MoveScore sums the move score of the steps in effect.
+func (h *History) MoveScore() int {
+	score := 0
+	for _, s := range h.Steps() {
+		score += s.Score
+	}
+	return score
+}
+
+// Moves are the moves of the steps in effect, in order.
+func (h *History) Moves() []hex.Move {
+	ms := []hex.Move{}
+	for _, s := range h.Steps() {
+		ms = append(ms, s.Moves...)
+	}
+	return ms
+}
+
+// LastMoves are at most the last n moves of the steps in effect.
+func (h *History) LastMoves(n int) []hex.Move {
+	ms := []hex.Move{}
+	for i := h.pos - 1; i >= 0 && len(ms) < n; i-- {
+		ms = append(append([]hex.Move{}, h.steps[i].Moves...), ms...)
+	}
+	if len(ms) > n {
+		return ms[len(ms)-n:]
+	}
+	return ms
+}
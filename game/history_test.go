@@ -0,0 +1,49 @@
+package game
+
+import "testing"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+
+func atomAt(x, y int) hex.Unit {
+	c := hex.Cell{X: x, Y: y}
+	return hex.Unit{Members: []hex.Cell{c}, Pivot: c}
+}
+
+func TestHistory(t *testing.T) {
+	start := board.NewBoard(2, 2, []hex.Cell{hex.Cell{X: 1, Y: 1}})
+	h := NewHistory(start)
+
+	if h.Undo() || h.Redo() {
+		t.Errorf("Expected nothing to undo or redo in a new history")
+	}
+
+	if s := h.Lock(0, atomAt(0, 1), []hex.Move{hex.SE, hex.SE}); s.Cleared != 1 || s.Score != 101 {
+		t.Errorf("Expected the first step to clear the bottom row, got %+v", s)
+	}
+	h.Lock(0, atomAt(0, 1), []hex.Move{hex.SE, hex.SE})
+	if h.Len() != 2 || h.MoveScore() != 102 || len(h.Moves()) != 4 || !h.Board().IsFilled(hex.Cell{X: 0, Y: 1}) {
+		t.Errorf("Expected two steps scoring 102, got %v %v %v\n%v", h.Len(), h.MoveScore(), h.Moves(), h.Board())
+	}
+
+	if !h.Undo() || h.Len() != 1 || h.MoveScore() != 101 || h.Board().IsFilled(hex.Cell{X: 0, Y: 1}) {
+		t.Errorf("Expected undo to take back the second step, got %v %v\n%v", h.Len(), h.MoveScore(), h.Board())
+	}
+
+	b := h.Branch()
+	b.Lock(0, atomAt(1, 1), []hex.Move{hex.SE, hex.SE})
+	if b.Len() != 2 || !b.Board().IsFilled(hex.Cell{X: 1, Y: 1}) {
+		t.Errorf("Expected branch to lock its own step, got\n%v", b.Board())
+	}
+
+	if !h.Redo() || h.Redo() || h.Len() != 2 || !h.Board().IsFilled(hex.Cell{X: 0, Y: 1}) {
+		t.Errorf("Expected redo to put back the second step untouched by the branch, got\n%v", h.Board())
+	}
+
+	h.Undo()
+	h.Undo()
+	h.Lock(0, atomAt(0, 0), []hex.Move{hex.SW})
+	if h.Redo() || h.Len() != 1 || h.MoveScore() != 1 {
+		t.Errorf("Expected locking after undo to drop the undone steps, got %v %v", h.Len(), h.MoveScore())
+	}
+}
@@ -0,0 +1,98 @@
+package game
+
+import "fmt"
+import "strings"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+import "github.com/mneise/icfp15/phrases"
+
+type SimResult struct {
+	MoveScore  int
+	PowerScore int
+	Score      int
+	Placed     int
+	// Consumed counts the commands played before the game ended, any
+	// commands after that are ignored.
+	Consumed int
+	// Dead counts the cells of the final board no unit can reach, see
+	// board.DeadCells.
+	Dead int
+	Err  error
+}
+
+// Simulate replays a solution for one seed of a program the way the
+// contest server does and scores it. Revisiting a position or an unknown
+// command is an error and scores zero.
+func Simulate(p Program, seed int, solution string) SimResult {
+	return SimulateWith(p, seed, solution, phrases.PowerPhrases)
+}
+
+// SimulateWith is Simulate scoring the given phrases, keyed by their
+// spelling as in phrases.PowerPhrases.
+func SimulateWith(p Program, seed int, solution string, ps map[string]string) SimResult {
+	return SimulateSource(p, p.Source(seed), solution, ps)
+}
+
+// SimulateSource is SimulateWith spawning the units of the source, which
+// it uses up.
+func SimulateSource(p Program, src UnitSource, solution string, ps map[string]string) SimResult {
+	r := SimResult{}
+	b := board.NewBoard(p.Height, p.Width, p.Filled)
+	solution = strings.ToLower(solution)
+
+	next := 0
+	cleared := 0
+	u := hex.Unit{}
+	visited := map[string]bool{}
+	spawn := func() bool {
+		i, ok := src.Next()
+		if !ok {
+			return false
+		}
+		u = b.StartLocation(p.Units[i])
+		next++
+		visited = map[string]bool{u.Position(): true}
+		return b.IsValid(u)
+	}
+
+	alive := spawn()
+	for _, c := range solution {
+		if !alive {
+			break
+		}
+		if c == '\t' || c == '\n' || c == '\r' {
+			r.Consumed++
+			continue
+		}
+
+		m, ok := phrases.CommandMoves[c]
+		if !ok {
+			r.Err = fmt.Errorf("unknown command %q at %v", c, r.Consumed)
+			return r
+		}
+		r.Consumed++
+
+		nu := u.Move(m)
+		if b.IsValid(nu) {
+			if visited[nu.Position()] {
+				r.Err = fmt.Errorf("unit %v revisits %v at command %v", next, nu.Members, r.Consumed)
+				return r
+			}
+			visited[nu.Position()] = true
+			u = nu
+			continue
+		}
+
+		clearedOld := cleared
+		b, cleared = b.FillCells(u.Members).ClearFullRows()
+		r.MoveScore += MoveScore(len(u.Members), cleared, clearedOld)
+		r.Placed++
+		alive = spawn()
+	}
+
+	r.Dead = len(b.DeadCells())
+	r.PowerScore = phrases.Score(solution[:r.Consumed], ps)
+	r.Score = r.MoveScore + r.PowerScore
+	return r
+}
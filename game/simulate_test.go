@@ -0,0 +1,57 @@
+package game
+
+import "testing"
+
+import "github.com/mneise/icfp15/hex"
+
+func TestSimulate(t *testing.T) {
+	p := LoadProblems("../testdata")[90]
+
+	data := []struct {
+		solution string
+		score    int
+		placed   int
+		consumed int
+		err      bool
+	}{
+		// drop to the right, clearing the bottom row
+		{solution: "ll", score: 101, placed: 1, consumed: 2},
+		// commands are case insensitive
+		{solution: "LLl", score: 101, placed: 1, consumed: 3},
+		// game over after two units, the rest is ignored
+		{solution: "lllei!", score: 102, placed: 2, consumed: 5},
+		// back and forth revisits the spawn position
+		{solution: "bp", err: true},
+		{solution: "l#", err: true},
+		{solution: "ei!", score: 407, placed: 1, consumed: 3},
+	}
+
+	for _, d := range data {
+		actual := Simulate(p, 0, d.solution)
+		if d.err {
+			if actual.Err == nil || actual.Score != 0 {
+				t.Errorf("Expected %q to fail, got: %+v", d.solution, actual)
+			}
+			continue
+		}
+		if actual.Err != nil || actual.Score != d.score ||
+			actual.Placed != d.placed || actual.Consumed != d.consumed {
+			t.Errorf("Failed to simulate %q, got: %+v expected score %v placed %v consumed %v",
+				d.solution, actual, d.score, d.placed, d.consumed)
+		}
+	}
+}
+
+func TestSimulateDeadCells(t *testing.T) {
+	// the bottom right cell is walled in
+	p := Program{
+		Units:        []hex.Unit{hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}},
+		Width:        3,
+		Height:       3,
+		Filled:       []hex.Cell{hex.Cell{X: 1, Y: 1}, hex.Cell{X: 2, Y: 1}, hex.Cell{X: 1, Y: 2}},
+		SourceLength: 1,
+	}
+	if actual := Simulate(p, 0, ""); actual.Dead != 1 {
+		t.Errorf("Expected one dead cell, got: %+v", actual)
+	}
+}
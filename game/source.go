@@ -0,0 +1,138 @@
+package game
+
+import "encoding/json"
+import "fmt"
+import "io/ioutil"
+import "math/rand"
+
+// UnitSource hands out the indexes of the units a game spawns, in order.
+type UnitSource interface {
+	// Next returns the next unit index, or false once the source ran out.
+	Next() (int, bool)
+	// Peek returns up to n of the next unit indexes without taking them.
+	Peek(n int) []int
+	// Remaining is the number of units left.
+	Remaining() int
+}
+
+// Drain takes all unit indexes left in the source.
+func Drain(s UnitSource) []int {
+	is := make([]int, 0, s.Remaining())
+	for i, ok := s.Next(); ok; i, ok = s.Next() {
+		is = append(is, i)
+	}
+	return is
+}
+
+const (
+	lcgMultiplier = 1103515245
+	lcgIncrement  = 12345
+)
+
+// LCG is the contest's unit source: a linear congruential generator
+// modulo 2^32 whose bits 30..16 pick the unit.
+type LCG struct {
+	x     uint32
+	units int
+	left  int
+}
+
+// NewLCG returns the source of length units out of units for the seed.
+func NewLCG(seed int, length int, units int) *LCG {
+	return &LCG{x: uint32(seed), units: units, left: length}
+}
+
+func (l *LCG) Next() (int, bool) {
+	if l.left <= 0 {
+		return 0, false
+	}
+	l.left--
+	return l.random() % l.units, true
+}
+
+// random returns the generator's next number and advances it.
+func (l *LCG) random() int {
+	r := int(l.x >> 16 & 0x7fff)
+	l.x = l.x*lcgMultiplier + lcgIncrement
+	return r
+}
+
+func (l *LCG) Peek(n int) []int {
+	c := *l
+	is := []int{}
+	for len(is) < n {
+		i, ok := c.Next()
+		if !ok {
+			break
+		}
+		is = append(is, i)
+	}
+	return is
+}
+
+func (l *LCG) Remaining() int {
+	return l.left
+}
+
+// Source is the contest's unit source for one of the program's seeds.
+func (p Program) Source(seed int) UnitSource {
+	return NewLCG(seed, p.SourceLength, len(p.Units))
+}
+
+// FixedSource hands out a given list of unit indexes.
+type FixedSource struct {
+	is []int
+}
+
+func NewFixedSource(is []int) *FixedSource {
+	return &FixedSource{is: is}
+}
+
+// ReadFixedSource reads a json list of unit indexes, each less than units.
+func ReadFixedSource(path string, units int) (*FixedSource, error) {
+	in, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	is := []int{}
+	if err := json.Unmarshal(in, &is); err != nil {
+		return nil, err
+	}
+	for _, i := range is {
+		if i < 0 || i >= units {
+			return nil, fmt.Errorf("unit %v out of range, have %v units", i, units)
+		}
+	}
+	return NewFixedSource(is), nil
+}
+
+func (f *FixedSource) Next() (int, bool) {
+	if len(f.is) == 0 {
+		return 0, false
+	}
+	i := f.is[0]
+	f.is = f.is[1:]
+	return i, true
+}
+
+func (f *FixedSource) Peek(n int) []int {
+	if n > len(f.is) {
+		n = len(f.is)
+	}
+	return append([]int{}, f.is[:n]...)
+}
+
+func (f *FixedSource) Remaining() int {
+	return len(f.is)
+}
+
+// NewRandomSource returns length units picked uniformly out of units, to
+// try solvers on sequences the contest's generator doesn't make.
+func NewRandomSource(seed int64, length int, units int) *FixedSource {
+	r := rand.New(rand.NewSource(seed))
+	is := make([]int, length)
+	for i := range is {
+		is[i] = r.Intn(units)
+	}
+	return NewFixedSource(is)
+}
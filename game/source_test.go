@@ -0,0 +1,126 @@
+package game
+
+import "io/ioutil"
+import "os"
+import "path/filepath"
+import "testing"
+
+// intRandom is the generator as first written with int arithmetic, which
+// produced the sequences the contest accepted our solutions for.
+func intRandom(s int, l int) []int {
+	rands := make([]int, l)
+	m := 1 << 31
+	a := 1103515245
+	c := 12345
+	x := s
+	for i := 0; i < l; i++ {
+		rands[i] = (x >> 16) & 0x7fff
+		x = ((a*x + c) % m)
+		if x < 0 {
+			x += 4294967296
+		}
+	}
+	return rands
+}
+
+func TestLCGAllSeeds(t *testing.T) {
+	ps := LoadProblems("..")
+	if len(ps) == 0 {
+		t.Fatalf("Expected to find the problems")
+	}
+	for _, p := range ps {
+		for _, seed := range p.SourceSeeds {
+			expected := CalcUnitIndexes(intRandom(seed, p.SourceLength), len(p.Units))
+			actual := Drain(p.Source(seed))
+			if len(actual) != len(expected) {
+				t.Fatalf("problem %v seed %v: expected %v units, but was: %v", p.Id, seed, len(expected), len(actual))
+			}
+			for i := range expected {
+				if actual[i] != expected[i] {
+					t.Fatalf("problem %v seed %v: expected unit %v to be %v, but was: %v", p.Id, seed, i, expected[i], actual[i])
+				}
+			}
+		}
+	}
+}
+
+// specSequences are the first random numbers of seeds the problems use,
+// seed 17 as published with the contest spec, the others worked out by
+// hand from the spec's formula.
+var specSequences = map[int][]int{
+	0:     []int{0, 0, 21468, 9988, 22117, 3498, 16927, 16045, 19741, 12122},
+	17:    []int{0, 24107, 16552, 12125, 9427, 13152, 21440, 3383, 6873, 16117},
+	18705: []int{0, 27272, 7856, 2605, 32527, 12537, 10454, 16868, 24442, 3392},
+	32620: []int{0, 8337, 6927, 15533, 31276, 30859, 29368, 11611, 4201, 31312},
+	32719: []int{0, 4161, 24561, 27981, 1711, 6122, 24814, 13050, 29494, 10244},
+}
+
+func TestLCGSpecSequences(t *testing.T) {
+	for seed, expected := range specSequences {
+		l := NewLCG(seed, len(expected), 1<<15)
+		actual := Drain(l)
+		for i := range expected {
+			if len(actual) != len(expected) || actual[i] != expected[i] {
+				t.Errorf("seed %v: expected random numbers to be: %v, but was: %v", seed, expected, actual)
+				break
+			}
+		}
+	}
+}
+
+func TestLCGPeek(t *testing.T) {
+	s := NewLCG(17, 4, 1000)
+	if actual := s.Peek(2); len(actual) != 2 || actual[0] != 0 || actual[1] != 107 {
+		t.Errorf("Expected to peek [0 107], but was: %v", actual)
+	}
+	if i, ok := s.Next(); !ok || i != 0 || s.Remaining() != 3 {
+		t.Errorf("Expected peeking not to take units, got %v %v with %v left", i, ok, s.Remaining())
+	}
+	if actual := s.Peek(5); len(actual) != 3 || actual[2] != 125 {
+		t.Errorf("Expected to peek [107 552 125], but was: %v", actual)
+	}
+	Drain(s)
+	if i, ok := s.Next(); ok || s.Remaining() != 0 {
+		t.Errorf("Expected the source to run out, got %v with %v left", i, s.Remaining())
+	}
+}
+
+func TestReadFixedSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "units.json")
+	ioutil.WriteFile(path, []byte("[1, 0, 2]"), 0644)
+
+	s, err := ReadFixedSource(path, 3)
+	if err != nil {
+		t.Fatalf("Expected to read the source: %v", err)
+	}
+	if actual := s.Peek(5); len(actual) != 3 || actual[0] != 1 || actual[2] != 2 {
+		t.Errorf("Expected to peek [1 0 2], but was: %v", actual)
+	}
+	if actual := Drain(s); len(actual) != 3 || s.Remaining() != 0 {
+		t.Errorf("Expected to take [1 0 2], but was: %v", actual)
+	}
+
+	if _, err := ReadFixedSource(path, 2); err == nil {
+		t.Errorf("Expected unit 2 to be out of range")
+	}
+}
+
+func TestRandomSource(t *testing.T) {
+	is := Drain(NewRandomSource(3, 100, 4))
+	again := Drain(NewRandomSource(3, 100, 4))
+	seen := map[int]bool{}
+	for i := range is {
+		if is[i] < 0 || is[i] >= 4 || is[i] != again[i] {
+			t.Fatalf("Expected the same units below 4, but were: %v and %v", is, again)
+		}
+		seen[is[i]] = true
+	}
+	if len(is) != 100 || len(seen) != 4 {
+		t.Errorf("Expected 100 units using all 4, but were: %v", is)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/mneise/icfp15
+
+go 1.18
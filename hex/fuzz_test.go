@@ -0,0 +1,98 @@
+package hex
+
+import "testing"
+
+// fuzzUnit builds a unit around the pivot from pairs of signed offsets.
+func fuzzUnit(px, py int16, data []byte) Unit {
+	u := Unit{Pivot: Cell{int(px), int(py)}}
+	for i := 0; i+1 < len(data) && len(u.Members) < 10; i += 2 {
+		u.Members = append(u.Members, Cell{int(px) + int(int8(data[i])), int(py) + int(int8(data[i+1]))})
+	}
+	if len(u.Members) == 0 {
+		u.Members = []Cell{u.Pivot}
+	}
+	return u
+}
+
+func FuzzCube(f *testing.F) {
+	f.Add(int16(0), int16(0))
+	f.Add(int16(3), int16(-7))
+	f.Add(int16(-1), int16(-1))
+	f.Fuzz(func(t *testing.T, x, y int16) {
+		c := Cell{int(x), int(y)}
+		q := c.Cube()
+		if q.X+q.Y+q.Z != 0 {
+			t.Errorf("cube coordinates of %v don't sum to zero: %v", c, q)
+		}
+		if actual := q.Cell(); actual != c {
+			t.Errorf("cube %v of %v converts back to %v", q, c, actual)
+		}
+
+		for _, m := range []Move{E, W, SE, SW} {
+			n := c.Move(m)
+			if d := c.Distance(n); d != 1 {
+				t.Errorf("move %v from %v to %v has distance %v", m, c, n, d)
+			}
+		}
+		if actual := c.Move(E).Move(W); actual != c {
+			t.Errorf("E then W from %v ends at %v", c, actual)
+		}
+		if actual := c.Move(W).Move(E); actual != c {
+			t.Errorf("W then E from %v ends at %v", c, actual)
+		}
+	})
+}
+
+func FuzzRotate(f *testing.F) {
+	f.Add(int16(0), int16(0), []byte{0, 0, 2, 0})
+	f.Add(int16(-3), int16(5), []byte{1, 255, 0, 1, 254, 3})
+	f.Fuzz(func(t *testing.T, px, py int16, data []byte) {
+		u := fuzzUnit(px, py, data)
+
+		for _, m := range []Move{RC, RCC} {
+			r := u
+			for i := 0; i < 6; i++ {
+				r = r.Move(m)
+				for mi, c := range r.Members {
+					d := c.Distance(u.Pivot)
+					if e := u.Members[mi].Distance(u.Pivot); d != e {
+						t.Errorf("%v changed distance of %v to the pivot from %v to %v", m, u.Members[mi], e, d)
+					}
+				}
+			}
+			if !equalsUnit(r, u) {
+				t.Errorf("six %v rotations of %v end at %v", m, u, r)
+			}
+		}
+
+		if actual := u.Move(RC).Move(RCC); !equalsUnit(actual, u) {
+			t.Errorf("RC then RCC of %v ends at %v", u, actual)
+		}
+	})
+}
+
+func FuzzMoveTo(f *testing.F) {
+	f.Add(int16(0), int16(0), int16(1), int16(1), []byte{0, 0, 2, 0})
+	f.Add(int16(4), int16(1), int16(-2), int16(-5), []byte{1, 0, 0, 1, 255, 2})
+	f.Fuzz(func(t *testing.T, px, py, x, y int16, data []byte) {
+		u := fuzzUnit(px, py, data)
+		c := Cell{int(x), int(y)}
+		actual := u.MoveTo(c, u.Pivot)
+
+		if actual.Pivot != c {
+			t.Errorf("moved %v to %v but pivot is at %v", u, c, actual.Pivot)
+		}
+		d := u.Pivot.Distance(c)
+		for i := range u.Members {
+			if md := u.Members[i].Distance(actual.Members[i]); md != d {
+				t.Errorf("member %v moved %v instead of %v", u.Members[i], md, d)
+			}
+			for j := range u.Members {
+				e := u.Members[i].Distance(u.Members[j])
+				if a := actual.Members[i].Distance(actual.Members[j]); a != e {
+					t.Errorf("moving %v to %v changed its shape: %v", u, c, actual)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package hex
+
+import "math"
+
+// Add is the hex d away from c.
+func (c Cube) Add(d Cube) Cube {
+	return Cube{X: c.X + d.X, Y: c.Y + d.Y, Z: c.Z + d.Z}
+}
+
+// Sub is the offset from d to c, the inverse of Add.
+func (c Cube) Sub(d Cube) Cube {
+	return Cube{X: c.X - d.X, Y: c.Y - d.Y, Z: c.Z - d.Z}
+}
+
+// Scale is the offset c repeated k times.
+func (c Cube) Scale(k int) Cube {
+	return Cube{X: c.X * k, Y: c.Y * k, Z: c.Z * k}
+}
+
+// Direction names the six neighbors of a hex, counter-clockwise from
+// east, and indexes Directions. North is up, towards row zero.
+type Direction int
+
+const (
+	East Direction = iota
+	NorthEast
+	NorthWest
+	West
+	SouthWest
+	SouthEast
+)
+
+// Neighbor is the adjacent hex in the direction d.
+func (c Cube) Neighbor(d Direction) Cube {
+	return c.Add(Directions[d])
+}
+
+// Neighbor is the adjacent cell in the direction d.
+func (c Cell) Neighbor(d Direction) Cell {
+	return c.Cube().Neighbor(d).Cell()
+}
+
+// Distance is the number of steps between neighbors it takes from c to d.
+func (c Cube) Distance(d Cube) int {
+	v := c.Sub(d)
+	n := abs(v.X)
+	if y := abs(v.Y); y > n {
+		n = y
+	}
+	if z := abs(v.Z); z > n {
+		n = z
+	}
+	return n
+}
+
+// Distance is Cube.Distance for cells.
+func (c Cell) Distance(d Cell) int {
+	return c.Cube().Distance(d.Cube())
+}
+
+// RotateAround turns the cell by turns times 60 degrees around the
+// center, clockwise for positive turns and counter-clockwise otherwise.
+func (c Cell) RotateAround(center Cell, turns int) Cell {
+	o := center.Cube()
+	v := c.Cube().Sub(o)
+	for i := (turns%6 + 6) % 6; i > 0; i-- {
+		v = Cube{X: -v.Z, Y: -v.X, Z: -v.Y}
+	}
+	return o.Add(v).Cell()
+}
+
+// Axis names the cube coordinate a reflection keeps.
+type Axis int
+
+const (
+	XAxis Axis = iota
+	YAxis
+	// ZAxis keeps the row, it mirrors rows left to right.
+	ZAxis
+)
+
+// Reflect mirrors the cell across the axis through the center, swapping
+// the other two cube coordinates.
+func (c Cell) Reflect(center Cell, a Axis) Cell {
+	o := center.Cube()
+	v := c.Cube().Sub(o)
+	switch a {
+	case XAxis:
+		v = Cube{X: v.X, Y: v.Z, Z: v.Y}
+	case YAxis:
+		v = Cube{X: v.Z, Y: v.Y, Z: v.X}
+	case ZAxis:
+		v = Cube{X: v.Y, Y: v.X, Z: v.Z}
+	}
+	return o.Add(v).Cell()
+}
+
+// Ring lists the cells at the distance radius from the center, going
+// round counter-clockwise from the corner south west of it.
+func Ring(center Cell, radius int) []Cell {
+	if radius <= 0 {
+		return []Cell{center}
+	}
+	cs := []Cell{}
+	q := center.Cube().Add(Directions[SouthWest].Scale(radius))
+	for d := East; d <= SouthEast; d++ {
+		for i := 0; i < radius; i++ {
+			cs = append(cs, q.Cell())
+			q = q.Neighbor(d)
+		}
+	}
+	return cs
+}
+
+// Line lists the cells a straight line from a to b crosses, both ends
+// included, each a neighbor of the one before.
+func Line(a, b Cell) []Cell {
+	p, q := a.Cube(), b.Cube()
+	n := p.Distance(q)
+	cs := []Cell{a}
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		// nudged off the edges between hexes so ties round the same way
+		// along the line
+		cs = append(cs, roundCube(
+			lerp(p.X, q.X, t)+1e-6,
+			lerp(p.Y, q.Y, t)+2e-6,
+			lerp(p.Z, q.Z, t)-3e-6).Cell())
+	}
+	return cs
+}
+
+func lerp(a, b int, t float64) float64 {
+	return float64(a) + float64(b-a)*t
+}
+
+// roundCube is the hex the fractional cube coordinates lie in.
+func roundCube(x, y, z float64) Cube {
+	rx, ry, rz := math.Round(x), math.Round(y), math.Round(z)
+	dx, dy, dz := math.Abs(rx-x), math.Abs(ry-y), math.Abs(rz-z)
+	switch {
+	case dx > dy && dx > dz:
+		rx = -ry - rz
+	case dy > dz:
+		ry = -rx - rz
+	default:
+		rz = -rx - ry
+	}
+	return Cube{X: int(rx), Y: int(ry), Z: int(rz)}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,190 @@
+package hex
+
+import "testing"
+
+// grid are all cells with both coordinates within -8 and 8, odd and even
+// rows on either side of zero.
+func grid() []Cell {
+	cs := []Cell{}
+	for y := -8; y <= 8; y++ {
+		for x := -8; x <= 8; x++ {
+			cs = append(cs, Cell{X: x, Y: y})
+		}
+	}
+	return cs
+}
+
+func TestNeighbor(t *testing.T) {
+	moves := map[Move]Direction{E: East, W: West, SE: SouthEast, SW: SouthWest}
+	for _, c := range grid() {
+		seen := map[Cell]bool{}
+		for d := East; d <= SouthEast; d++ {
+			n := c.Neighbor(d)
+			if c.Distance(n) != 1 || seen[n] {
+				t.Fatalf("Expected %v to have six neighbors, got %v", c, c.Neighbors())
+			}
+			seen[n] = true
+			if actual := n.Neighbor((d + 3) % 6); actual != c {
+				t.Errorf("Expected the way back from %v to %v to end at %v, but was: %v", c, n, c, actual)
+			}
+		}
+		for m, d := range moves {
+			if c.Move(m) != c.Neighbor(d) {
+				t.Errorf("Expected %v from %v to be %v, but was: %v", m, c, c.Neighbor(d), c.Move(m))
+			}
+		}
+	}
+
+	// row zero is even, so its north east neighbor is above it
+	if actual := (Cell{X: 2, Y: 0}).Neighbor(NorthEast); actual != (Cell{X: 2, Y: -1}) {
+		t.Errorf("Expected north east of (2, 0) to be (2, -1), but was: %v", actual)
+	}
+	if actual := (Cell{X: 2, Y: 1}).Neighbor(NorthWest); actual != (Cell{X: 2, Y: 0}) {
+		t.Errorf("Expected north west of (2, 1) to be (2, 0), but was: %v", actual)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	// the distance is the number of steps a breadth first search takes,
+	// which stays within the grid for cells close enough to the middle
+	origin := Cell{X: 0, Y: 1}
+	steps := map[Cell]int{origin: 0}
+	todo := []Cell{origin}
+	for len(todo) > 0 {
+		c := todo[0]
+		todo = todo[1:]
+		for _, n := range c.Neighbors() {
+			if _, ok := steps[n]; !ok && n.X >= -12 && n.X <= 12 && n.Y >= -12 && n.Y <= 12 {
+				steps[n] = steps[c] + 1
+				todo = append(todo, n)
+			}
+		}
+	}
+
+	for _, c := range grid() {
+		if s := steps[c]; s <= 4 && c.Distance(origin) != s {
+			t.Errorf("Expected %v to be %v steps from %v, but was: %v", c, s, origin, c.Distance(origin))
+		}
+		if c.Distance(origin) != origin.Distance(c) {
+			t.Errorf("Expected the distance between %v and %v to be symmetric", c, origin)
+		}
+	}
+
+	if actual := (Cell{X: 0, Y: 0}).Distance(Cell{X: 3, Y: 2}); actual != 4 {
+		t.Errorf("Expected (3, 2) to be 4 from (0, 0), but was: %v", actual)
+	}
+}
+
+func TestRotateAround(t *testing.T) {
+	centers := []Cell{Cell{X: 0, Y: 0}, Cell{X: 1, Y: 1}, Cell{X: -2, Y: 3}}
+	for _, o := range centers {
+		for _, c := range grid() {
+			r := c
+			for i := 0; i < 6; i++ {
+				r = r.RotateAround(o, 1)
+				if r.Distance(o) != c.Distance(o) {
+					t.Fatalf("Expected turning %v around %v to keep its distance, but was at %v", c, o, r)
+				}
+			}
+			if r != c {
+				t.Errorf("Expected six turns of %v around %v to end at it, but was: %v", c, o, r)
+			}
+			if actual := c.RotateAround(o, 1); actual != c.Rotate(o, RC) {
+				t.Errorf("Expected a clockwise turn of %v around %v to be %v, but was: %v", c, o, c.Rotate(o, RC), actual)
+			}
+			if actual := c.RotateAround(o, -1); actual != c.Rotate(o, RCC) {
+				t.Errorf("Expected a counter-clockwise turn of %v around %v to be %v, but was: %v", c, o, c.Rotate(o, RCC), actual)
+			}
+			if actual := c.RotateAround(o, 2); actual != c.RotateAround(o, -4) {
+				t.Errorf("Expected two turns of %v around %v to be four back, got %v and %v", c, o, actual, c.RotateAround(o, -4))
+			}
+		}
+	}
+
+	// east of the center turns to south east
+	if actual := (Cell{X: 2, Y: 2}).RotateAround(Cell{X: 1, Y: 2}, 1); actual != (Cell{X: 1, Y: 3}) {
+		t.Errorf("Expected (2, 2) to turn around (1, 2) to (1, 3), but was: %v", actual)
+	}
+}
+
+func TestReflect(t *testing.T) {
+	o := Cell{X: 1, Y: 1}
+	for _, c := range grid() {
+		for _, a := range []Axis{XAxis, YAxis, ZAxis} {
+			r := c.Reflect(o, a)
+			if r.Reflect(o, a) != c {
+				t.Errorf("Expected reflecting %v twice across %v to end at it, but was: %v", c, a, r.Reflect(o, a))
+			}
+			if r.Distance(o) != c.Distance(o) {
+				t.Errorf("Expected reflecting %v across %v to keep its distance, but was: %v", c, a, r)
+			}
+		}
+		if r := c.Reflect(o, ZAxis); r.Y != c.Y || c.Y == o.Y && r.X-o.X != o.X-c.X {
+			t.Errorf("Expected reflecting %v across the row axis to mirror its row, but was: %v", c, r)
+		}
+		// two reflections make a turn
+		if actual := c.Reflect(o, XAxis).Reflect(o, ZAxis); actual != c.RotateAround(o, -2) {
+			t.Errorf("Expected reflecting %v across two axes to turn it to %v, but was: %v", c, c.RotateAround(o, -2), actual)
+		}
+	}
+}
+
+func TestRing(t *testing.T) {
+	o := Cell{X: 1, Y: 2}
+	within := map[Cell]bool{}
+	for r := 0; r <= 5; r++ {
+		cs := Ring(o, r)
+		if r == 0 && (len(cs) != 1 || cs[0] != o) {
+			t.Errorf("Expected the ring of radius 0 to be the center, but was: %v", cs)
+		}
+		if r > 0 && len(cs) != 6*r {
+			t.Errorf("Expected %v cells at radius %v, but was: %v", 6*r, r, cs)
+		}
+		for i, c := range cs {
+			if c.Distance(o) != r || within[c] {
+				t.Errorf("Expected %v to be a new cell at radius %v", c, r)
+			}
+			within[c] = true
+			if next := cs[(i+1)%len(cs)]; r > 0 && c.Distance(next) != 1 {
+				t.Errorf("Expected ring cells %v and %v of radius %v to be neighbors", c, next, r)
+			}
+		}
+	}
+
+	for _, c := range grid() {
+		if c.Distance(o) <= 5 && !within[c] {
+			t.Errorf("Expected %v to be on one of the rings", c)
+		}
+	}
+
+	if actual := Ring(o, 1)[0]; actual != o.Neighbor(SouthWest) {
+		t.Errorf("Expected the ring to start south west of the center, but was: %v", actual)
+	}
+}
+
+func TestLine(t *testing.T) {
+	for _, a := range []Cell{Cell{X: 0, Y: 0}, Cell{X: 3, Y: -1}} {
+		for _, b := range grid() {
+			cs := Line(a, b)
+			if len(cs) != a.Distance(b)+1 || cs[0] != a || cs[len(cs)-1] != b {
+				t.Fatalf("Expected the line from %v to %v to take %v steps, but was: %v", a, b, a.Distance(b), cs)
+			}
+			for i, c := range cs {
+				if c.Distance(a) != i || c.Distance(b) != len(cs)-1-i {
+					t.Errorf("Expected the line from %v to %v to go straight, but was: %v", a, b, cs)
+					break
+				}
+			}
+		}
+	}
+
+	// the line along a row stays in it
+	expected := []Cell{Cell{X: 0, Y: 3}, Cell{X: 1, Y: 3}, Cell{X: 2, Y: 3}}
+	actual := Line(Cell{X: 0, Y: 3}, Cell{X: 2, Y: 3})
+	for i := range expected {
+		if len(actual) != len(expected) || actual[i] != expected[i] {
+			t.Errorf("Expected the line to be %v, but was: %v", expected, actual)
+			break
+		}
+	}
+}
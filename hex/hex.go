@@ -0,0 +1,240 @@
+// Package hex implements the odd-r offset hex grid of the game: cells,
+// their cube coordinates, moves and units of cells moving around a pivot,
+// and the geometry of distances, rotations, reflections, rings and lines.
+package hex
+
+import "fmt"
+import "math"
+import "sort"
+
+// Cell is a hex in odd-r offset coordinates, odd rows are shifted right.
+type Cell struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Unit is a group of cells that moves and rotates around its pivot. The
+// pivot need not be one of the members.
+type Unit struct {
+	Members []Cell `json:"members"`
+	Pivot   Cell   `json:"pivot"`
+}
+
+type Move int
+
+const (
+	E Move = iota
+	W
+	SE
+	SW
+	RC
+	RCC
+)
+
+// Cube is a hex in cube coordinates, where X+Y+Z is always zero.
+type Cube struct {
+	X int
+	Y int
+	Z int
+}
+
+// Directions are the cube offsets to the six neighbors of a hex.
+var Directions = []Cube{
+	Cube{1, -1, 0}, Cube{1, 0, -1}, Cube{0, 1, -1},
+	Cube{-1, 1, 0}, Cube{-1, 0, 1}, Cube{0, -1, 1},
+}
+
+func (c Cell) Cube() Cube {
+	// http://www.redblobgames.com/grids/hexagons/
+	// # convert odd-r offset to cube
+	// x = col - (row - (row&1)) / 2
+	// z = row
+	// y = -x-z
+	x := c.X - (c.Y-(c.Y&1))/2
+	z := c.Y
+	y := -x - z
+
+	return Cube{X: x, Y: y, Z: z}
+}
+
+func (c Cube) Cell() Cell {
+	// http://www.redblobgames.com/grids/hexagons/
+	// # convert cube to odd-r offset
+	// col = x + (z - (z&1)) / 2
+	// row = z
+	return Cell{X: c.X + (c.Z-(c.Z&1))/2, Y: c.Z}
+}
+
+func (m Move) String() string {
+	switch {
+	case m == E:
+		return "E"
+	case m == SE:
+		return "SE"
+	case m == W:
+		return "W"
+	case m == SW:
+		return "SW"
+	case m == RC:
+		return "RC"
+	case m == RCC:
+		return "RCC"
+	}
+	return "?"
+}
+
+// moveDirections are the directions of the moves that aren't rotations.
+var moveDirections = [...]Direction{E: East, W: West, SE: SouthEast, SW: SouthWest}
+
+// Move returns the neighbor of the cell in the direction of m. Rotations
+// need a pivot, see Rotate.
+func (c Cell) Move(m Move) Cell {
+	if m < 0 || int(m) >= len(moveDirections) {
+		return Cube{-1, -1, -1}.Cell()
+	}
+	return c.Neighbor(moveDirections[m])
+}
+
+func (c Cell) Neighbors() []Cell {
+	q := c.Cube()
+	ns := []Cell{}
+	for _, d := range Directions {
+		ns = append(ns, q.Add(d).Cell())
+	}
+	return ns
+}
+
+// Rotate turns a cell by 60 degrees around the pivot, clockwise for RC
+// and counter-clockwise for RCC.
+func (c Cell) Rotate(pivot Cell, m Move) Cell {
+	switch m {
+	case RC:
+		return c.RotateAround(pivot, 1)
+	case RCC:
+		return c.RotateAround(pivot, -1)
+	}
+	return c
+}
+
+func (c Cell) ShiftX(offset int) Cell {
+	return Cell{X: c.X + offset, Y: c.Y}
+}
+
+func (c Cell) ShiftY(offset int) Cell {
+	return Cell{X: c.X, Y: c.Y + offset}
+}
+
+// Move moves or rotates the whole unit.
+func (u Unit) Move(m Move) (nu Unit) {
+	if m == RC || m == RCC {
+		return u.Rotate(m)
+	}
+
+	nu.Pivot = u.Pivot.Move(m)
+	for _, x := range u.Members {
+		nu.Members = append(nu.Members, x.Move(m))
+	}
+	return nu
+}
+
+func (u Unit) Rotate(m Move) (nu Unit) {
+	nu.Pivot = u.Pivot
+	for _, x := range u.Members {
+		nu.Members = append(nu.Members, x.Rotate(u.Pivot, m))
+	}
+	return nu
+}
+
+// MoveTo translates the unit so that the cell at old ends up at new.
+func (u Unit) MoveTo(new Cell, old Cell) Unit {
+	return u.Translate(new.Cube().Sub(old.Cube()))
+}
+
+// Translate moves every cell of the unit by the cube offset d.
+func (u Unit) Translate(d Cube) Unit {
+	tu := Unit{Pivot: u.Pivot.Cube().Add(d).Cell()}
+	for _, m := range u.Members {
+		tu.Members = append(tu.Members, m.Cube().Add(d).Cell())
+	}
+	return tu
+}
+
+func (u Unit) Contains(c Cell) bool {
+	for _, m := range u.Members {
+		if m == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Position identifies the cells a unit occupies, independent of member
+// order, so that rotations of symmetric units count as the same position.
+func (u Unit) Position() string {
+	cs := make([]Cell, len(u.Members))
+	copy(cs, u.Members)
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].Y < cs[j].Y || (cs[i].Y == cs[j].Y && cs[i].X < cs[j].X)
+	})
+	return fmt.Sprint(cs)
+}
+
+func (u Unit) Width() int {
+	minX := math.MaxInt32
+	maxX := -1
+
+	for _, member := range u.Members {
+		if minX > member.X {
+			minX = member.X
+		}
+		if maxX < member.X {
+			maxX = member.X
+		}
+	}
+
+	return 1 + maxX - minX
+}
+
+func (u Unit) MinYCell() Cell {
+	minY := math.MaxInt32
+	c := Cell{}
+
+	for _, member := range u.Members {
+		if minY > member.Y {
+			minY = member.Y
+			c = member
+		}
+	}
+
+	return c
+}
+
+func (u Unit) MinXCell() Cell {
+	minX := math.MaxInt32
+	c := Cell{}
+
+	for _, member := range u.Members {
+		if minX > member.X {
+			minX = member.X
+			c = member
+		}
+	}
+
+	return c
+}
+
+func (u Unit) Height() int {
+	minY := math.MaxInt32
+	maxY := -1
+
+	for _, member := range u.Members {
+		if minY > member.Y {
+			minY = member.Y
+		}
+		if maxY < member.Y {
+			maxY = member.Y
+		}
+	}
+
+	return 1 + maxY - minY
+}
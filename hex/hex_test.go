@@ -0,0 +1,313 @@
+package hex
+
+import "testing"
+
+func equalsUnit(actual Unit, expected Unit) bool {
+	if actual.Height() != expected.Height() ||
+		actual.Width() != expected.Width() {
+		return false
+	}
+
+	for i := range expected.Members {
+		if actual.Members[i] != expected.Members[i] {
+			return false
+		}
+	}
+
+	if expected.Pivot != actual.Pivot {
+		return false
+	}
+
+	return true
+}
+
+func TestUnitWidth(t *testing.T) {
+	atoms := []struct {
+		atom     Unit
+		expected int
+	}{
+		{
+			atom:     Unit{Members: []Cell{Cell{0, 0}}, Pivot: Cell{0, 0}},
+			expected: 1,
+		},
+		{
+			atom:     Unit{Members: []Cell{Cell{0, 0}, Cell{2, 0}}, Pivot: Cell{1, 0}},
+			expected: 3,
+		},
+	}
+
+	for _, data := range atoms {
+		actual := data.atom.Width()
+
+		if actual != data.expected {
+			t.Errorf("Failed identify width: %v expected %v", actual, data.expected)
+		}
+	}
+
+}
+
+func TestUnitHeight(t *testing.T) {
+	atoms := []struct {
+		atom     Unit
+		expected int
+	}{
+		{
+			atom:     Unit{Members: []Cell{Cell{0, 0}}, Pivot: Cell{0, 0}},
+			expected: 1,
+		},
+		{
+			atom:     Unit{Members: []Cell{Cell{0, 0}, Cell{2, 2}}, Pivot: Cell{1, 1}},
+			expected: 3,
+		},
+	}
+
+	for _, data := range atoms {
+		actual := data.atom.Height()
+
+		if actual != data.expected {
+			t.Errorf("Failed identify height: %v expected %v", actual, data.expected)
+		}
+	}
+
+}
+
+func TestMoveCell(t *testing.T) {
+	data := []struct {
+		c        Move
+		s        Cell
+		expected Cell
+	}{
+		{
+			c:        E,
+			s:        Cell{0, 0},
+			expected: Cell{1, 0},
+		},
+		{
+			c:        W,
+			s:        Cell{1, 0},
+			expected: Cell{0, 0},
+		},
+		{
+			c:        SE,
+			s:        Cell{0, 0},
+			expected: Cell{0, 1},
+		},
+		{
+			c:        SW,
+			s:        Cell{0, 0},
+			expected: Cell{-1, 1},
+		},
+		{
+			c:        SW,
+			s:        Cell{1, 0},
+			expected: Cell{0, 1},
+		},
+		{
+			c:        SE,
+			s:        Cell{1, 0},
+			expected: Cell{1, 1},
+		},
+		{
+			c:        SE,
+			s:        Cell{1, 1},
+			expected: Cell{2, 2},
+		},
+	}
+
+	for _, d := range data {
+		if actual := d.s.Move(d.c); actual != d.expected {
+			t.Errorf("incorrect move: actual %v expected %v", actual, d.expected)
+		}
+	}
+
+}
+
+func TestMoveCellBiggerBoard(t *testing.T) {
+	data := []struct {
+		c        Move
+		s        Cell
+		expected Cell
+	}{
+		{
+			c:        SE,
+			s:        Cell{0, 0},
+			expected: Cell{0, 1},
+		},
+		{
+			c:        SE,
+			s:        Cell{0, 1},
+			expected: Cell{1, 2},
+		},
+		{
+			c:        SW,
+			s:        Cell{0, 0},
+			expected: Cell{-1, 1},
+		},
+		{
+			c:        SW,
+			s:        Cell{1, 0},
+			expected: Cell{0, 1},
+		},
+		{
+			c:        SW,
+			s:        Cell{1, 1},
+			expected: Cell{1, 2},
+		},
+	}
+
+	for _, d := range data {
+		if actual := d.s.Move(d.c); actual != d.expected {
+			t.Errorf("incorrect move: actual %v expected %v", actual, d.expected)
+		}
+	}
+
+}
+
+func TestUnitMove(t *testing.T) {
+	// east
+	u := Unit{Members: []Cell{Cell{0, 0}, Cell{0, 1}, Cell{0, 2}}, Pivot: Cell{0, 0}}
+	actual := u.Move(E)
+	expected := Unit{Members: []Cell{Cell{1, 0}, Cell{1, 1}, Cell{1, 2}}, Pivot: Cell{1, 0}}
+
+	if actual.Pivot != expected.Pivot {
+		t.Errorf("wrong pivot: %v expected %v", actual.Pivot, expected.Pivot)
+	}
+	for mi, m := range expected.Members {
+		if m != actual.Members[mi] {
+			t.Errorf("wrong member: %v expected %v", actual.Members[mi], m)
+		}
+	}
+
+	// west
+	u = Unit{Members: []Cell{Cell{1, 0}, Cell{1, 1}, Cell{1, 2}}, Pivot: Cell{1, 0}}
+	actual = u.Move(W)
+	expected = Unit{Members: []Cell{Cell{0, 0}, Cell{0, 1}, Cell{0, 2}}, Pivot: Cell{0, 0}}
+
+	if actual.Pivot != expected.Pivot {
+		t.Errorf("wrong pivot: %v expected %v", actual.Pivot, expected.Pivot)
+	}
+	for mi, m := range expected.Members {
+		if m != actual.Members[mi] {
+			t.Errorf("wrong member: %v expected %v", actual.Members[mi], m)
+		}
+	}
+
+	// southeast
+	u = Unit{Members: []Cell{Cell{0, 0}, Cell{0, 1}, Cell{0, 2}}, Pivot: Cell{0, 0}}
+	actual = u.Move(SE)
+	expected = Unit{Members: []Cell{Cell{0, 1}, Cell{1, 2}, Cell{0, 3}}, Pivot: Cell{0, 1}}
+
+	if actual.Pivot != expected.Pivot {
+		t.Errorf("wrong pivot: %v expected %v", actual.Pivot, expected.Pivot)
+	}
+	for mi, m := range expected.Members {
+		if m != actual.Members[mi] {
+			t.Errorf("wrong member: %v expected %v", actual.Members[mi], m)
+		}
+	}
+
+	// southwest
+	u = Unit{Members: []Cell{Cell{1, 0}, Cell{1, 1}, Cell{1, 2}}, Pivot: Cell{1, 0}}
+	actual = u.Move(SW)
+	expected = Unit{Members: []Cell{Cell{0, 1}, Cell{1, 2}, Cell{0, 3}}, Pivot: Cell{0, 1}}
+
+	if actual.Pivot != expected.Pivot {
+		t.Errorf("wrong pivot: %v expected %v", actual.Pivot, expected.Pivot)
+	}
+	for mi, m := range expected.Members {
+		if m != actual.Members[mi] {
+			t.Errorf("wrong member: %v expected %v", actual.Members[mi], m)
+		}
+	}
+}
+
+func TestMoveTriplet(t *testing.T) {
+
+	// east
+	u := Unit{Members: []Cell{Cell{0, 0}, Cell{0, 1}, Cell{0, 2}}, Pivot: Cell{0, 0}}
+	actual := u.MoveTo(Cell{1, 0}, u.Pivot)
+	expected := Unit{Members: []Cell{Cell{1, 0}, Cell{1, 1}, Cell{1, 2}}, Pivot: Cell{1, 0}}
+
+	if actual.Pivot != expected.Pivot {
+		t.Errorf("wrong pivot: %v expected %v", actual.Pivot, expected.Pivot)
+	}
+	for mi, m := range expected.Members {
+		if m != actual.Members[mi] {
+			t.Errorf("wrong member: %v expected %v", actual.Members[mi], m)
+		}
+	}
+
+	// west
+	actual = expected.MoveTo(Cell{0, 0}, expected.Pivot)
+	expected = Unit{Members: []Cell{Cell{0, 0}, Cell{0, 1}, Cell{0, 2}}, Pivot: Cell{0, 0}}
+
+	if actual.Pivot != expected.Pivot {
+		t.Errorf("wrong pivot: %v expected %v", actual.Pivot, expected.Pivot)
+	}
+	for mi, m := range expected.Members {
+		if m != actual.Members[mi] {
+			t.Errorf("wrong member: %v expected %v", actual.Members[mi], m)
+		}
+	}
+
+	// southeast
+	u = Unit{Members: []Cell{Cell{0, 0}, Cell{0, 1}, Cell{0, 2}}, Pivot: Cell{0, 0}}
+	expected = Unit{Members: []Cell{Cell{0, 1}, Cell{1, 2}, Cell{0, 3}}, Pivot: Cell{0, 1}}
+	actual = u.MoveTo(Cell{0, 1}, u.Pivot)
+
+	if actual.Pivot != expected.Pivot {
+		t.Errorf("wrong pivot: %v expected %v", actual.Pivot, expected.Pivot)
+	}
+	for mi, m := range expected.Members {
+		if m != actual.Members[mi] {
+			t.Errorf("wrong member: %v expected %v", actual.Members[mi], m)
+		}
+	}
+
+	// southwest
+	actual = expected.MoveTo(Cell{0, 1}, expected.Pivot)
+	expected = Unit{Members: []Cell{Cell{0, 1}, Cell{1, 2}, Cell{0, 3}}, Pivot: Cell{0, 1}}
+
+	if actual.Pivot != expected.Pivot {
+		t.Errorf("wrong pivot: %v expected %v", actual.Pivot, expected.Pivot)
+	}
+	for mi, m := range expected.Members {
+		if m != actual.Members[mi] {
+			t.Errorf("wrong member: %v expected %v", actual.Members[mi], m)
+		}
+	}
+}
+
+func TestUnitRelativeToCell(t *testing.T) {
+	unit := Unit{Members: []Cell{Cell{0, 0}}, Pivot: Cell{0, 0}}
+	cell := Cell{1, 1}
+	actual := unit.MoveTo(cell, unit.Pivot)
+	expected := Unit{Members: []Cell{Cell{1, 1}}, Pivot: Cell{1, 1}}
+
+	if !equalsUnit(actual, expected) {
+		t.Errorf("Failed to get relative cell, got cell: %v expected %v", actual, expected)
+	}
+
+	unit = Unit{Members: []Cell{Cell{0, 0}, Cell{2, 0}}, Pivot: Cell{1, 0}}
+	cell = Cell{0, 3}
+	actual = unit.MoveTo(cell, unit.Pivot)
+	expected = Unit{Members: []Cell{Cell{-1, 3}, Cell{1, 3}}, Pivot: Cell{0, 3}}
+
+	if !equalsUnit(actual, expected) {
+		t.Errorf("Failed to get relative cell, got cell: %v expected %v", actual, expected)
+	}
+}
+
+func TestUnitRotate(t *testing.T) {
+	u := Unit{Members: []Cell{Cell{0, 0}, Cell{2, 0}}, Pivot: Cell{1, 0}}
+	actual := u.Move(RC)
+	expected := Unit{Members: []Cell{Cell{0, -1}, Cell{1, 1}}, Pivot: Cell{1, 0}}
+
+	if !equalsUnit(actual, expected) {
+		t.Errorf("Failed to rotate clockwise, got unit: %v expected %v", actual, expected)
+	}
+
+	if actual = actual.Move(RCC); !equalsUnit(actual, u) {
+		t.Errorf("Failed to rotate back, got unit: %v expected %v", actual, u)
+	}
+}
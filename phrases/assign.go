@@ -0,0 +1,172 @@
+package phrases
+
+import "math/bits"
+import "sort"
+import "strings"
+
+import "github.com/mneise/icfp15/hex"
+
+// automaton is an Aho-Corasick automaton over the command characters,
+// telling which phrases end after every character.
+type automaton struct {
+	trans []map[rune]int
+	// out lists the phrases ending in each state, through failure links too.
+	out [][]int
+}
+
+func newAutomaton(phrases []string) automaton {
+	a := automaton{trans: []map[rune]int{map[rune]int{}}, out: [][]int{nil}}
+	for j, p := range phrases {
+		s := 0
+		for _, r := range p {
+			n, ok := a.trans[s][r]
+			if !ok {
+				n = len(a.trans)
+				a.trans = append(a.trans, map[rune]int{})
+				a.out = append(a.out, nil)
+				a.trans[s][r] = n
+			}
+			s = n
+		}
+		a.out[s] = append(a.out[s], j)
+	}
+
+	// complete the transitions breadth first, so the failure state of
+	// every state is done before it
+	fail := make([]int, len(a.trans))
+	todo := []int{}
+	for r := range CommandMoves {
+		if n, ok := a.trans[0][r]; ok {
+			todo = append(todo, n)
+		} else {
+			a.trans[0][r] = 0
+		}
+	}
+	for len(todo) > 0 {
+		s := todo[0]
+		todo = todo[1:]
+		a.out[s] = append(a.out[s], a.out[fail[s]]...)
+		for r := range CommandMoves {
+			if n, ok := a.trans[s][r]; ok {
+				fail[n] = a.trans[fail[s]][r]
+				todo = append(todo, n)
+			} else {
+				a.trans[s][r] = a.trans[fail[s]][r]
+			}
+		}
+	}
+	return a
+}
+
+type assignKey struct {
+	state int
+	used  uint64
+}
+
+type assignEntry struct {
+	value int
+	prev  int
+	c     rune
+}
+
+// Assign spells the moves choosing among the characters of each move the
+// ones that Score rates highest for the phrases, keyed by spelling as in
+// PowerPhrases. It never changes the moves. Dynamic programming over the
+// automaton state and the phrases used so far finds the best spelling,
+// the default characters winning ties. More than 64 phrases fall back to
+// Insert.
+//
+// Keys that can't catch up with the best one in the same automaton state
+// are dropped: the same moves ahead score the same for both, except for
+// 300 for each phrase the best key used and they didn't.
+func Assign(ms []hex.Move, ps map[string]string) string {
+	phrases := []string{}
+	for _, p := range ps {
+		phrases = append(phrases, strings.ToLower(p))
+	}
+	sort.Strings(phrases)
+
+	if len(phrases) > 64 {
+		return Insert(strings.Join(MovesToCommands(ms), ""), ps)
+	}
+
+	a := newAutomaton(phrases)
+	// every layer lists its keys in the order they were reached, which
+	// keeps ties deterministic
+	keys := [][]assignKey{[]assignKey{assignKey{}}}
+	entries := [][]assignEntry{[]assignEntry{assignEntry{}}}
+
+	for _, m := range ms {
+		prevKeys, prevEntries := keys[len(keys)-1], entries[len(entries)-1]
+		index := map[assignKey]int{}
+		nextKeys := []assignKey{}
+		nextEntries := []assignEntry{}
+
+		for i, k := range prevKeys {
+			for _, c := range Commands[m] {
+				r := rune(c[0])
+				nk := assignKey{state: a.trans[k.state][r], used: k.used}
+				v := prevEntries[i].value
+				for _, j := range a.out[nk.state] {
+					v += 2 * len(phrases[j])
+					if nk.used&(1<<uint(j)) == 0 {
+						v += 300
+						nk.used |= 1 << uint(j)
+					}
+				}
+
+				e := assignEntry{value: v, prev: i, c: r}
+				if n, ok := index[nk]; !ok {
+					index[nk] = len(nextKeys)
+					nextKeys = append(nextKeys, nk)
+					nextEntries = append(nextEntries, e)
+				} else if v > nextEntries[n].value {
+					nextEntries[n] = e
+				}
+			}
+		}
+
+		nextKeys, nextEntries = prune(nextKeys, nextEntries)
+		keys = append(keys, nextKeys)
+		entries = append(entries, nextEntries)
+	}
+
+	last := entries[len(entries)-1]
+	best := 0
+	for i := range last {
+		if last[i].value > last[best].value {
+			best = i
+		}
+	}
+
+	rs := make([]rune, len(ms))
+	for l := len(ms); l > 0; l-- {
+		e := entries[l][best]
+		rs[l-1] = e.c
+		best = e.prev
+	}
+	return string(rs)
+}
+
+// prune drops the keys that are dominated by the best key in their state,
+// keeping the order of the others.
+func prune(keys []assignKey, entries []assignEntry) ([]assignKey, []assignEntry) {
+	best := map[int]assignKey{}
+	values := map[int]int{}
+	for i, k := range keys {
+		if v, ok := values[k.state]; !ok || entries[i].value > v {
+			best[k.state] = k
+			values[k.state] = entries[i].value
+		}
+	}
+
+	ks, es := []assignKey{}, []assignEntry{}
+	for i, k := range keys {
+		if entries[i].value+300*bits.OnesCount64(best[k.state].used&^k.used) < values[k.state] {
+			continue
+		}
+		ks = append(ks, k)
+		es = append(es, entries[i])
+	}
+	return ks, es
+}
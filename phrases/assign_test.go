@@ -0,0 +1,168 @@
+package phrases
+
+import "math/rand"
+import "strings"
+import "testing"
+
+import "github.com/mneise/icfp15/hex"
+
+var allMoves = []hex.Move{hex.E, hex.W, hex.SE, hex.SW, hex.RC, hex.RCC}
+
+func movesOf(t *testing.T, s string) []hex.Move {
+	ms := []hex.Move{}
+	for _, r := range s {
+		m, ok := CommandMoves[r]
+		if !ok {
+			t.Fatalf("%q is not a command", r)
+		}
+		ms = append(ms, m)
+	}
+	return ms
+}
+
+// bestScore tries every spelling of the moves.
+func bestScore(ms []hex.Move, ps map[string]string) int {
+	best := 0
+	var try func(s string)
+	try = func(s string) {
+		if len(s) == len(ms) {
+			if score := Score(s, ps); score > best {
+				best = score
+			}
+			return
+		}
+		for _, c := range Commands[ms[len(s)]] {
+			try(s + c)
+		}
+	}
+	try("")
+	return best
+}
+
+func TestAssign(t *testing.T) {
+	ms := movesOf(t, "bapaaplaapbapaa")
+	actual := Assign(ms, PowerPhrases)
+
+	if expected := "ei!ia! ia!ei!aa"; actual != expected {
+		t.Errorf("Expected solution to be: %v, but was: %v", expected, actual)
+	}
+}
+
+func TestAssignOverlapping(t *testing.T) {
+	// "ei!" and "!ei" overlap, replacing one clobbers the other
+	ps := map[string]string{"bap": "ei!", "pba": "!ei"}
+	ms := movesOf(t, "bapba")
+
+	actual := Assign(ms, ps)
+	if score := Score(actual, ps); score != 2*3+300+2*3+300 {
+		t.Errorf("Expected both phrases in %q, scored %v", actual, score)
+	}
+}
+
+func TestAssignIsOptimal(t *testing.T) {
+	ps := map[string]string{"bap": "ei!", "pba": "!ei", "aap": "ia!"}
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		ms := []hex.Move{}
+		for j := 0; j < 1+r.Intn(6); j++ {
+			ms = append(ms, []hex.Move{hex.E, hex.W, hex.SW}[r.Intn(3)])
+		}
+
+		actual := Assign(ms, ps)
+		if len(actual) != len(ms) {
+			t.Fatalf("Expected one command per move, got %q for %v", actual, ms)
+		}
+		for j, m := range movesOf(t, actual) {
+			if m != ms[j] {
+				t.Errorf("Expected %q to spell the moves %v", actual, ms)
+			}
+		}
+		if score, expected := Score(actual, ps), bestScore(ms, ps); score != expected {
+			t.Errorf("Expected %q for %v to score %v, but was: %v", actual, ms, expected, score)
+		}
+	}
+}
+
+func TestAssignBeatsInsert(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 50; i++ {
+		ms := []hex.Move{}
+		for j := 0; j < 200; j++ {
+			ms = append(ms, allMoves[r.Intn(4)])
+		}
+
+		s := ""
+		for _, c := range MovesToCommands(ms) {
+			s += c
+		}
+		if actual, inserted := Score(Assign(ms, PowerPhrases), PowerPhrases), Score(Insert(s, PowerPhrases), PowerPhrases); actual < inserted {
+			t.Errorf("Expected assigning to score at least %v, but was: %v", inserted, actual)
+		}
+	}
+}
+
+// contestPhrases are the phrases of power known after the contest.
+var contestPhrases = []string{"ei!", "ia! ia!", "r'lyeh", "yuggoth", "tsathoggua",
+	"yogsothoth", "necronomicon", "vigintillion", "cthulhu fhtagn!", "the laundry",
+	"planet 10", "monkeyboy", "john bigboote", "yoyodyne", "blue hades",
+	"case nightmare green", "in his house at r'lyeh dead cthulhu waits dreaming.",
+	"ph'nglui mglw'nafh cthulhu r'lyeh wgah'nagl fhtagn!"}
+
+// phraseMoves are n moves made up of the phrases' moves with single moves
+// in between, which keeps lots of partial phrases open at once.
+func phraseMoves(r *rand.Rand, n int) []hex.Move {
+	ms := []hex.Move{}
+	for len(ms) < n {
+		if r.Intn(2) == 0 {
+			ms = append(ms, allMoves[r.Intn(4)])
+			continue
+		}
+		pms, _ := Moves(contestPhrases[r.Intn(len(contestPhrases))])
+		ms = append(ms, pms...)
+	}
+	return ms[:n]
+}
+
+func TestAssignManyPhrases(t *testing.T) {
+	ps, err := Table(contestPhrases)
+	if err != nil {
+		t.Fatalf("Failed to spell the phrases: %v", err)
+	}
+	r := rand.New(rand.NewSource(4))
+
+	// without pruning the used phrases, these took hours
+	for i := 0; i < 5; i++ {
+		ms := phraseMoves(r, 1000)
+		s := strings.Join(MovesToCommands(ms), "")
+		if actual, inserted := Score(Assign(ms, ps), ps), Score(Insert(s, ps), ps); actual < inserted {
+			t.Errorf("Expected assigning to score at least %v, but was: %v", inserted, actual)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		ms := phraseMoves(r, 1+r.Intn(6))
+		if score, expected := Score(Assign(ms, ps), ps), bestScore(ms, ps); score != expected {
+			t.Errorf("Expected %v to score %v, but was: %v", ms, expected, score)
+		}
+	}
+}
+
+func BenchmarkAssign(b *testing.B) {
+	r := rand.New(rand.NewSource(3))
+	ms := []hex.Move{}
+	for j := 0; j < 10000; j++ {
+		ms = append(ms, allMoves[r.Intn(4)])
+	}
+	for i := 0; i < b.N; i++ {
+		Assign(ms, PowerPhrases)
+	}
+}
+
+func BenchmarkAssignManyPhrases(b *testing.B) {
+	ps, _ := Table(contestPhrases)
+	ms := phraseMoves(rand.New(rand.NewSource(5)), 1000)
+	for i := 0; i < b.N; i++ {
+		Assign(ms, ps)
+	}
+}
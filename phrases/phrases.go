@@ -0,0 +1,141 @@
+// Package phrases maps moves to command characters and scores the phrases
+// of power spelled by a solution.
+package phrases
+
+import "fmt"
+import "strings"
+
+import "github.com/mneise/icfp15/hex"
+
+// Commands are the interchangeable characters for each move.
+var Commands = map[hex.Move][]string{
+	hex.E:   []string{"b", "c", "e", "f", "y", "2"},
+	hex.W:   []string{"p", "'", "!", ".", "0", "3"},
+	hex.SE:  []string{"l", "m", "n", "o", " ", "5"},
+	hex.SW:  []string{"a", "g", "h", "i", "j", "4"},
+	hex.RC:  []string{"d", "q", "r", "v", "z", "1"},
+	hex.RCC: []string{"k", "s", "t", "u", "w", "x"},
+}
+
+// CommandMoves maps every command character back to its move.
+var CommandMoves = map[rune]hex.Move{}
+
+func init() {
+	for m, cs := range Commands {
+		for _, c := range cs {
+			CommandMoves[rune(c[0])] = m
+		}
+	}
+}
+
+// there are eighteen phrases of power
+var PowerPhrases = map[string]string{
+	// RC SW SE E SE RCC RC E RC
+	// "dalblkdbd": "vancouver",
+	// E RCC SW SW SE RCC SW
+	// "bkaalka": "yuggoth",
+	// SW SW W SE SW SW E => a a p l a a p
+	"aaplaap": "ia! ia!",
+	// RC W SE E E SW => d p l b b a
+	// "dplbba": "r'lyeh",
+	// E SW W => b a p
+	"bap": "ei!",
+}
+
+// InsertPowerPhrases replaces the default spelling of phrases' moves in
+// a solution with the phrases themselves.
+func InsertPowerPhrases(s string) string {
+	return Insert(s, PowerPhrases)
+}
+
+// Insert is InsertPowerPhrases for the given phrases, keyed by their
+// default spelling.
+func Insert(s string, ps map[string]string) string {
+	ns := s
+	for k, v := range ps {
+		ns = strings.Replace(ns, k, v, -1)
+	}
+	return ns
+}
+
+// CalcPowerScore scores 2*len for every occurrence of a phrase, plus 300
+// for each phrase used at least once.
+func CalcPowerScore(s string) int {
+	return Score(s, PowerPhrases)
+}
+
+// Score is CalcPowerScore for the given phrases.
+func Score(s string, ps map[string]string) int {
+	score := 0
+
+	for _, v := range ps {
+		if len(v) > len(s) {
+			continue
+		}
+
+		count := Count(s, v)
+		score += 2 * len(v) * count
+		if count > 0 {
+			score += 300
+		}
+	}
+
+	return score
+}
+
+// Count is the number of times the phrase occurs in s, overlapping
+// occurrences included, as the contest counts them.
+func Count(s string, phrase string) int {
+	count := 0
+	for i := len(phrase); i <= len(s); i++ {
+		if phrase == s[i-len(phrase):i] {
+			count++
+		}
+	}
+	return count
+}
+
+// Spelling spells the moves of a phrase with their default characters,
+// the key phrases are looked up by in Insert and Score.
+func Spelling(phrase string) (string, error) {
+	ms, err := Moves(phrase)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(MovesToCommands(ms), ""), nil
+}
+
+// Moves are the moves a phrase spells.
+func Moves(phrase string) ([]hex.Move, error) {
+	ms := []hex.Move{}
+	for _, r := range strings.ToLower(phrase) {
+		m, ok := CommandMoves[r]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a command in phrase %q", r, phrase)
+		}
+		ms = append(ms, m)
+	}
+	return ms, nil
+}
+
+// Table keys the given phrases by their spelling, as in PowerPhrases.
+func Table(ps []string) (map[string]string, error) {
+	t := map[string]string{}
+	for _, p := range ps {
+		k, err := Spelling(p)
+		if err != nil {
+			return nil, err
+		}
+		t[k] = strings.ToLower(p)
+	}
+	return t, nil
+}
+
+// MovesToCommands spells each move with its default character.
+func MovesToCommands(ms []hex.Move) []string {
+	cs := []string{}
+	for _, m := range ms {
+		cs = append(cs, Commands[m][0])
+	}
+	return cs
+}
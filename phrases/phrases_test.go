@@ -0,0 +1,112 @@
+package phrases
+
+import "testing"
+
+import "github.com/mneise/icfp15/hex"
+
+func TestMovesToCommands(t *testing.T) {
+	cs := []hex.Move{hex.E, hex.SE, hex.RC}
+	expected := []string{"b", "l", "d"}
+	actual := MovesToCommands(cs)
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("Expected command sequence to be: %v but was: %v", expected, actual)
+		}
+	}
+}
+
+func TestInsertPowerPhrases(t *testing.T) {
+	s := "bap"
+	expected := "ei!"
+	actual := InsertPowerPhrases(s)
+
+	if expected != actual {
+		t.Errorf("Expected solution to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	s = "bapbap"
+	expected = "ei!ei!"
+	actual = InsertPowerPhrases(s)
+
+	if expected != actual {
+		t.Errorf("Expected solution to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	s = "bapaaplaapbap"
+	expected = "ei!ia! ia!ei!"
+	actual = InsertPowerPhrases(s)
+
+	if expected != actual {
+		t.Errorf("Expected solution to be: %v, but was: %v",
+			expected, actual)
+	}
+}
+
+func TestCalcPowerScore(t *testing.T) {
+	s := "ei!"
+	expected := 306
+	actual := CalcPowerScore(s)
+
+	if expected != actual {
+		t.Errorf("Expected solution to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	s = "ei!ei!"
+	expected = 312
+	actual = CalcPowerScore(s)
+
+	if expected != actual {
+		t.Errorf("Expected solution to be: %v, but was: %v",
+			expected, actual)
+	}
+
+	s = "ei!ia! ia!ei!"
+	expected = 626
+	actual = CalcPowerScore(s)
+
+	if expected != actual {
+		t.Errorf("Expected solution to be: %v, but was: %v",
+			expected, actual)
+	}
+}
+
+func TestCount(t *testing.T) {
+	data := []struct {
+		s        string
+		phrase   string
+		expected int
+	}{
+		{"ia! ia!", "ia! ia!", 1},
+		{"ia! ia! ia!", "ia! ia!", 2},
+		{"555", "55", 2},
+		{"ei!", "ia! ia!", 0},
+	}
+	for _, d := range data {
+		if actual := Count(d.s, d.phrase); actual != d.expected {
+			t.Errorf("Expected %q to occur %v times in %q, but was: %v", d.phrase, d.expected, d.s, actual)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	actual, err := Table([]string{"Ei!", "ia! ia!"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(actual) != len(PowerPhrases) {
+		t.Errorf("Expected table to be: %v, but was: %v", PowerPhrases, actual)
+	}
+	for k, v := range PowerPhrases {
+		if actual[k] != v {
+			t.Errorf("Expected table to be: %v, but was: %v", PowerPhrases, actual)
+		}
+	}
+
+	if _, err := Table([]string{"ei?"}); err == nil {
+		t.Errorf("Expected an error for a phrase that isn't made of commands")
+	}
+}
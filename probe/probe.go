@@ -0,0 +1,114 @@
+// Package probe tells real phrases of power from guesses: it plans
+// minimal solutions spelling a single candidate phrase once, so the power
+// score the server returns for one shows whether the phrase is real.
+package probe
+
+import "encoding/json"
+import "fmt"
+import "net/http"
+import "strings"
+
+import "github.com/mneise/icfp15/contest"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+import "github.com/mneise/icfp15/phrases"
+
+// MaxPrefix bounds the moves a probe makes before spelling its phrase,
+// to find room for it.
+const MaxPrefix = 6
+
+// prefixMoves are the moves a probe makes room with.
+var prefixMoves = []hex.Move{hex.SE, hex.SW, hex.E, hex.W}
+
+// quiet spells each move with a digit, as phrases are words.
+var quiet = map[hex.Move]string{}
+
+func init() {
+	for m, cs := range phrases.Commands {
+		quiet[m] = cs[len(cs)-1]
+	}
+}
+
+// Probe is a solution spelling one candidate phrase once and no other
+// phrase known.
+type Probe struct {
+	Phrase string
+	Output game.Output
+	// MoveScore is what the solution scores without phrases.
+	MoveScore int
+}
+
+// Expected is the score the probe gets if its phrase is real.
+func (pr Probe) Expected() int {
+	return pr.MoveScore + 2*len(pr.Phrase) + 300
+}
+
+// IsReal tells whether the score the server returned for the probe
+// counts its phrase.
+func (pr Probe) IsReal(score int) bool {
+	return score == pr.Expected()
+}
+
+func (pr Probe) String() string {
+	return fmt.Sprintf("%q: %q scores %v if real, %v otherwise", pr.Phrase, pr.Output.Solution, pr.Expected(), pr.MoveScore)
+}
+
+// Plan finds the shortest solution for one of the seeds of the program
+// spelling the candidate once, after making room with at most MaxPrefix
+// moves if needed. The solution contains none of the known phrases, so
+// the server scores the candidate alone.
+func Plan(p game.Program, candidate string, known []string) (Probe, error) {
+	candidate = strings.ToLower(candidate)
+	ms, err := phrases.Moves(candidate)
+	if err != nil {
+		return Probe{}, err
+	}
+	table, err := phrases.Table(known)
+	if err != nil {
+		return Probe{}, err
+	}
+	delete(table, strings.Join(phrases.MovesToCommands(ms), ""))
+
+	for _, seed := range p.SourceSeeds {
+		prefixes := []string{""}
+		for n := 0; n <= MaxPrefix; n++ {
+			next := []string{}
+			for _, prefix := range prefixes {
+				sol := prefix + candidate
+				r := game.Simulate(p, seed, sol)
+				if r.Err == nil && r.Consumed == len(sol) && phrases.Count(sol, candidate) == 1 && phrases.Score(sol, table) == 0 {
+					return Probe{
+						Phrase:    candidate,
+						Output:    game.Output{ProblemId: p.Id, Seed: seed, Tag: "probe " + candidate, Solution: sol},
+						MoveScore: r.MoveScore,
+					}, nil
+				}
+				for _, m := range prefixMoves {
+					next = append(next, prefix+quiet[m])
+				}
+			}
+			prefixes = next
+		}
+	}
+	return Probe{}, fmt.Errorf("no room to spell %q on problem %v", candidate, p.Id)
+}
+
+// Submit posts the probe on its own and returns the score the server
+// answers with, as the mock server does.
+func Submit(client *http.Client, c contest.SubmitConfig, pr Probe) (int, error) {
+	rs := contest.Submit(client, c, []game.Output{pr.Output})
+	if len(rs) != 1 {
+		return 0, fmt.Errorf("expected one submission, got %v", rs)
+	}
+	if rs[0].Err != nil {
+		return 0, rs[0].Err
+	}
+	scored := []contest.ScoredOutput{}
+	if err := json.Unmarshal([]byte(rs[0].Body), &scored); err != nil || len(scored) != 1 {
+		return 0, fmt.Errorf("can't read score from %q: %v", rs[0].Body, err)
+	}
+	if scored[0].Error != "" {
+		return 0, fmt.Errorf("solution failed: %v", scored[0].Error)
+	}
+	return scored[0].Score, nil
+}
@@ -0,0 +1,58 @@
+package probe
+
+import "io/ioutil"
+import "net/http/httptest"
+import "testing"
+
+import "github.com/mneise/icfp15/contest"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/phrases"
+
+func TestPlan(t *testing.T) {
+	in, err := ioutil.ReadFile("../p2.json")
+	if err != nil {
+		t.Fatalf("can't open problem: %v", err)
+	}
+	p := *game.ReadProgram(in)
+	known := []string{"ei!", "ia! ia!"}
+	table, _ := phrases.Table(append(known, "yuggoth"))
+
+	for _, c := range []string{"yuggoth", "ei!", "r'lyeh"} {
+		pr, err := Plan(p, c, known)
+		if err != nil {
+			t.Fatalf("Failed to plan probe for %v: %v", c, err)
+		}
+		if phrases.Count(pr.Output.Solution, c) != 1 {
+			t.Errorf("Expected %q to spell %v once", pr.Output.Solution, c)
+		}
+		r := game.SimulateWith(p, pr.Output.Seed, pr.Output.Solution, table)
+		if r.Err != nil || r.MoveScore != pr.MoveScore {
+			t.Errorf("Expected %v to be valid, got %+v", pr, r)
+		}
+	}
+
+	if _, err := Plan(p, "ei?", known); err == nil {
+		t.Errorf("Expected an error for a phrase that isn't made of commands")
+	}
+}
+
+func TestProbeMockServer(t *testing.T) {
+	ps := game.LoadProblems("..")
+	s := contest.NewMockServer("secret", ps)
+	s.Phrases, _ = phrases.Table([]string{"ei!", "yuggoth"})
+	server := httptest.NewServer(s)
+	defer server.Close()
+	c := contest.SubmitConfig{BaseURL: server.URL, TeamId: 260, Token: "secret"}
+
+	expected := map[string]bool{"yuggoth": true, "r'lyeh": false, "ei!": true}
+	for phrase, real := range expected {
+		pr, err := Plan(ps[2], phrase, []string{"ei!"})
+		if err != nil {
+			t.Fatalf("Failed to plan probe for %v: %v", phrase, err)
+		}
+		score, err := Submit(server.Client(), c, pr)
+		if err != nil || pr.IsReal(score) != real {
+			t.Errorf("Expected %v to be real: %v, got score %v for %v: %v", phrase, real, score, pr, err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package solver
+
+import "sort"
+import "time"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+
+const (
+	// backtrackAlternatives is how many targets besides the best one are
+	// kept for every step to backtrack to.
+	backtrackAlternatives = 2
+	// maxBacktracks bounds the retries of a game without a budget.
+	maxBacktracks = 200
+)
+
+type placement struct {
+	t     hex.Unit
+	m     []hex.Move
+	value float64
+}
+
+// rankedTargets lists the reachable targets best first as bestTarget
+// rates them, or a drop if none is reachable.
+func (o Options) rankedTargets(b board.Board, s hex.Unit) []placement {
+	ps := []placement{}
+	ts := ReachableTargets(b, s)
+	for t, ok := ts.Next(); ok; t, ok = ts.Next() {
+		m := MoveSequence(b, s, t)
+		if len(m) == 0 {
+			continue
+		}
+		nb, cleared := b.FillCells(t.Members).ClearFullRows()
+		ps = append(ps, placement{t: t, m: m, value: Evaluate(nb, cleared, o.weights())})
+	}
+	if len(ps) == 0 {
+		t, m := drop(b, s)
+		return []placement{placement{t: t, m: m}}
+	}
+
+	sort.SliceStable(ps, func(a, b int) bool {
+		return ps[a].value > ps[b].value
+	})
+	return ps
+}
+
+// backtrack plays like heuristic, but when a unit can't spawn it undoes
+// steps back to the last one with an untried alternative target and
+// carries on from there. It keeps the game scoring most moves.
+func backtrack(in Input, o Options) Solution {
+	start := time.Now()
+	p := in.Program
+	h := game.NewHistory(board.NewBoard(p.Height, p.Width, p.Filled))
+	is, end := o.units(in.source())
+	c := newCarry(in.phrases())
+	lock := func(pl placement) {
+		i := is[h.Len()]
+		b := h.Board()
+		m := c.route(b, b.StartLocation(p.Units[i]), pl.t, pl.m, h.LastMoves(c.longest))
+		h.Lock(i, pl.t, m)
+	}
+
+	best := h.Branch()
+	bestEnd := SpawnBlocked
+	// alternatives[k] are the targets left to try for step k.
+	alternatives := [][]placement{}
+	tries := 0
+
+	for {
+		if h.Len() == len(is) {
+			if h.MoveScore() >= best.MoveScore() {
+				best, bestEnd = h.Branch(), end
+			}
+			break
+		}
+
+		i := is[h.Len()]
+		b := h.Board()
+		s := b.StartLocation(p.Units[i])
+		if b.IsValid(s) {
+			ps := o.rankedTargets(b, s)
+			lock(ps[0])
+			if len(ps) > backtrackAlternatives+1 {
+				ps = ps[:backtrackAlternatives+1]
+			}
+			alternatives = append(alternatives, ps[1:])
+			continue
+		}
+
+		if h.MoveScore() > best.MoveScore() || best.Len() == 0 {
+			best, bestEnd = h.Branch(), SpawnBlocked
+		}
+		tries++
+		if (in.Budget > 0 && time.Since(start) > in.Budget) || (in.Budget == 0 && tries > maxBacktracks) {
+			break
+		}
+
+		for len(alternatives) > 0 && len(alternatives[len(alternatives)-1]) == 0 {
+			h.Undo()
+			alternatives = alternatives[:len(alternatives)-1]
+		}
+		if len(alternatives) == 0 {
+			break
+		}
+		k := len(alternatives) - 1
+		next := alternatives[k][0]
+		alternatives[k] = alternatives[k][1:]
+		h.Undo()
+		lock(next)
+		o.logMsg("backtracking")
+	}
+
+	return historyGame(best, in.phrases(), bestEnd).solution(start)
+}
+
+func init() {
+	Register("backtrack", func(o Options) Solver {
+		return SolverFunc(func(in Input) Solution {
+			return backtrack(in, o)
+		})
+	})
+}
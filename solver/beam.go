@@ -0,0 +1,117 @@
+package solver
+
+import "sort"
+import "time"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+import "github.com/mneise/icfp15/phrases"
+
+const DefaultBeamWidth = 4
+
+type beamState struct {
+	b         board.Board
+	moves     []hex.Move
+	moveScore int
+	cleared   int
+	placed    int
+	over      bool
+	value     float64
+}
+
+// place locks the unit u at t after the moves m.
+func (st beamState) place(u, t hex.Unit, m []hex.Move, w Weights) beamState {
+	nb, cleared := st.b.FillCells(t.Members).ClearFullRows()
+	ns := beamState{
+		b:         nb,
+		moves:     append(append([]hex.Move{}, st.moves...), m...),
+		moveScore: st.moveScore + game.MoveScore(len(u.Members), cleared, st.cleared),
+		cleared:   cleared,
+		placed:    st.placed + 1,
+	}
+	ns.value = float64(ns.moveScore) + Evaluate(nb, cleared, w)
+	return ns
+}
+
+// beam keeps the o.BeamWidth best games after every unit, rated by their
+// move score plus Evaluate of their board. Once the budget is spent it
+// carries on with the best game only, locking units at the first target.
+func beam(in Input, o Options) Solution {
+	start := time.Now()
+	p := in.Program
+	width := o.BeamWidth
+	if width <= 0 {
+		width = DefaultBeamWidth
+	}
+
+	is, end := o.units(in.source())
+	states := []beamState{beamState{b: board.NewBoard(p.Height, p.Width, p.Filled)}}
+	c := newCarry(in.phrases())
+
+	for _, i := range is {
+		hurry := in.Budget > 0 && time.Since(start) > in.Budget
+		if hurry {
+			states = states[:1]
+		}
+
+		next := []beamState{}
+		for _, st := range states {
+			if st.over {
+				next = append(next, st)
+				continue
+			}
+
+			u := p.Units[i]
+			s := st.b.StartLocation(u)
+			if !st.b.IsValid(s) {
+				st.over = true
+				next = append(next, st)
+				continue
+			}
+
+			found := false
+			ts := ReachableTargets(st.b, s)
+			for t, ok := ts.Next(); ok; t, ok = ts.Next() {
+				m := MoveSequence(st.b, s, t)
+				if len(m) == 0 {
+					continue
+				}
+				found = true
+				next = append(next, st.place(u, t, c.route(st.b, s, t, m, st.moves), o.weights()))
+
+				if hurry {
+					break
+				}
+			}
+
+			if !found {
+				t, m := drop(st.b, s)
+				next = append(next, st.place(u, t, c.route(st.b, s, t, m, st.moves), o.weights()))
+			}
+		}
+
+		sort.SliceStable(next, func(a, b int) bool {
+			return next[a].value > next[b].value
+		})
+		if len(next) > width {
+			next = next[:width]
+		}
+		states = next
+	}
+
+	best := states[0]
+	for _, st := range states {
+		if st.moveScore > best.moveScore {
+			best = st
+		}
+	}
+
+	g := Game{MoveScore: best.moveScore, Placed: best.placed, End: end}
+	if best.over {
+		g.End = SpawnBlocked
+	}
+	g.Solution = phrases.Assign(best.moves, in.phrases())
+	g.PowerScore = phrases.Score(g.Solution, in.phrases())
+	return g.solution(start)
+}
@@ -0,0 +1,140 @@
+package solver
+
+import "sort"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+import "github.com/mneise/icfp15/phrases"
+
+// lockOrder is the order lock moves are tried in, MoveSequence's first.
+var lockOrder = []hex.Move{hex.SE, hex.SW, hex.E, hex.W, hex.RC, hex.RCC}
+
+// carry finishes phrases of power across locks: a phrase started by a
+// unit's last moves can be finished by the next unit's first moves, as
+// phrases only score spelled contiguously, lock moves included.
+type carry struct {
+	phrases [][]hex.Move
+	// longest is the length of the longest phrase.
+	longest int
+}
+
+func newCarry(ps map[string]string) carry {
+	c := carry{}
+	names := []string{}
+	for _, p := range ps {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+	for _, p := range names {
+		if ms, err := phrases.Moves(p); err == nil && len(ms) > 0 {
+			c.phrases = append(c.phrases, ms)
+			if len(ms) > c.longest {
+				c.longest = len(ms)
+			}
+		}
+	}
+	return c
+}
+
+// progress is the longest end of ms that starts a phrase, the moves left
+// to finish that phrase and its index.
+func (c carry) progress(ms []hex.Move) (int, []hex.Move, int) {
+	best, pending, phrase := 0, []hex.Move(nil), -1
+	for j, p := range c.phrases {
+		for k := len(p); k > best; k-- {
+			if k <= len(ms) && equalMoves(ms[len(ms)-k:], p[:k]) {
+				best, pending, phrase = k, p[k:], j
+				break
+			}
+		}
+	}
+	return best, pending, phrase
+}
+
+// tail cuts the moves played so far down to those a phrase can continue.
+func (c carry) tail(played []hex.Move) []hex.Move {
+	if len(played) > c.longest {
+		return played[len(played)-c.longest:]
+	}
+	return played
+}
+
+// route takes the moves m locking the unit spawned at s at t and, where
+// the unit still makes it to t, starts them with the moves finishing the
+// phrase the moves played so far started. Either way it locks with the
+// move leaving most of a phrase spelled.
+func (c carry) route(b board.Board, s, t hex.Unit, m []hex.Move, played []hex.Move) []hex.Move {
+	if len(c.phrases) == 0 {
+		return m
+	}
+	tail := c.tail(played)
+
+	if _, pending, _ := c.progress(tail); len(pending) > 0 {
+		if pm := via(b, s, t, pending); len(pm) > 0 {
+			m = pm
+		}
+	}
+	return c.lock(b, t, m, tail)
+}
+
+// lock swaps the last of the moves m locking the unit at t for the lock
+// move leaving most of a phrase spelled after the tail played before.
+func (c carry) lock(b board.Board, t hex.Unit, m []hex.Move, tail []hex.Move) []hex.Move {
+	body := append(append([]hex.Move{}, c.tail(tail)...), m[:len(m)-1]...)
+	lock, best := m[len(m)-1], -1
+	for _, l := range lockOrder {
+		if b.IsValid(t.Move(l)) {
+			continue
+		}
+		if k, _, _ := c.progress(append(body, l)); k > best {
+			lock, best = l, k
+		}
+	}
+	return append(append([]hex.Move{}, m[:len(m)-1]...), lock)
+}
+
+// via is the moves taking the unit spawned at s along the moves first and
+// then to t, locking it there, or none if it can't get there that way
+// without revisiting a position.
+func via(b board.Board, s, t hex.Unit, first []hex.Move) []hex.Move {
+	u, ok := follow(b, s, first, map[string]bool{})
+	if !ok {
+		return nil
+	}
+	rest := MoveSequence(b, u, t)
+	if len(rest) == 0 {
+		return nil
+	}
+	m := append(append([]hex.Move{}, first...), rest...)
+	if lu, ok := follow(b, s, m[:len(m)-1], map[string]bool{}); !ok || lu.Position() != t.Position() {
+		return nil
+	}
+	return m
+}
+
+// follow moves the unit from s along ms, false if a move isn't valid or
+// revisits a position in seen.
+func follow(b board.Board, s hex.Unit, ms []hex.Move, seen map[string]bool) (hex.Unit, bool) {
+	u := s
+	seen[u.Position()] = true
+	for _, m := range ms {
+		u = u.Move(m)
+		if !b.IsValid(u) || seen[u.Position()] {
+			return u, false
+		}
+		seen[u.Position()] = true
+	}
+	return u, true
+}
+
+func equalMoves(a, b []hex.Move) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
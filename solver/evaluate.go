@@ -0,0 +1,97 @@
+package solver
+
+import "encoding/json"
+import "io/ioutil"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+
+// Weights of the board features Evaluate adds up. Positive weights reward
+// a feature, negative ones penalize it.
+type Weights struct {
+	Lines     float64 `json:"lines"`
+	Height    float64 `json:"height"`
+	Holes     float64 `json:"holes"`
+	Bumpiness float64 `json:"bumpiness"`
+	// Dead weighs the cells board.DeadCells says no unit can reach.
+	Dead float64 `json:"dead"`
+}
+
+// DefaultWeights were found by the tune command with the heuristic solver.
+var DefaultWeights = Weights{Lines: 228, Height: -2.6, Holes: -9.7, Bumpiness: -3.8, Dead: -0.2}
+
+// Evaluate rates a board after a unit locked and cleared some rows.
+func Evaluate(b board.Board, cleared int, w Weights) float64 {
+	hs := columnHeights(b)
+	height := 0
+	bumpiness := 0
+	for x, h := range hs {
+		height += h
+		if x > 0 {
+			d := h - hs[x-1]
+			if d < 0 {
+				d = -d
+			}
+			bumpiness += d
+		}
+	}
+
+	return w.Lines*float64(cleared) +
+		w.Height*float64(height) +
+		w.Holes*float64(countHoles(b)) +
+		w.Bumpiness*float64(bumpiness) +
+		w.Dead*float64(deadCells(b, w))
+}
+
+// deadCells counts the dead cells if they matter, flood filling the
+// board isn't free.
+func deadCells(b board.Board, w Weights) int {
+	if w.Dead == 0 {
+		return 0
+	}
+	return len(b.DeadCells())
+}
+
+func columnHeights(b board.Board) []int {
+	hs := make([]int, b.Width())
+	for x := range hs {
+		for y := range b {
+			if b[y][x] {
+				hs[x] = b.Height() - y
+				break
+			}
+		}
+	}
+	return hs
+}
+
+// A hole is an empty cell whose upper neighbors are all filled or off the
+// side of the board, no unit can drop into it anymore.
+func countHoles(b board.Board) int {
+	holes := 0
+	for y := 1; y < b.Height(); y++ {
+		for x := range b[y] {
+			c := hex.Cell{X: x, Y: y}
+			if b[y][x] {
+				continue
+			}
+			ne := c.Neighbor(hex.NorthEast)
+			nw := c.Neighbor(hex.NorthWest)
+			if !b.IsValidCell(ne) && !b.IsValidCell(nw) && (b.IsFilled(ne) || b.IsFilled(nw)) {
+				holes++
+			}
+		}
+	}
+	return holes
+}
+
+// ReadWeights reads weights as written by the tune command.
+func ReadWeights(path string) (Weights, error) {
+	w := Weights{}
+	in, err := ioutil.ReadFile(path)
+	if err != nil {
+		return w, err
+	}
+	err = json.Unmarshal(in, &w)
+	return w, err
+}
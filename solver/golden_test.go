@@ -0,0 +1,71 @@
+package solver
+
+import "encoding/json"
+import "flag"
+import "fmt"
+import "io/ioutil"
+import "path/filepath"
+import "testing"
+
+import "github.com/mneise/icfp15/game"
+
+var update = flag.Bool("update", false, "update the golden files")
+
+type golden struct {
+	Solution   string `json:"solution"`
+	MoveScore  int    `json:"moveScore"`
+	PowerScore int    `json:"powerScore"`
+}
+
+var goldenGames = []struct {
+	problem int
+	seed    int
+}{
+	{problem: 0, seed: 0},
+	{problem: 1, seed: 0},
+	{problem: 3, seed: 6876},
+	{problem: 6, seed: 0},
+	{problem: 11, seed: 0},
+}
+
+func readProgramFile(t testing.TB, problem int) game.Program {
+	in, err := ioutil.ReadFile(filepath.Join("..", fmt.Sprintf("p%v.json", problem)))
+	if err != nil {
+		t.Fatalf("can't open problem %v: %v", problem, err)
+	}
+	return *game.ReadProgram(in)
+}
+
+// Run with -update to regenerate the golden files after improving the solver.
+func TestGoldenGames(t *testing.T) {
+	for _, gg := range goldenGames {
+		p := readProgramFile(t, gg.problem)
+		g := Play(p, gg.seed, Options{})
+		r := game.Simulate(p, gg.seed, g.Solution)
+		actual := golden{Solution: g.Solution, MoveScore: r.MoveScore, PowerScore: r.PowerScore}
+
+		path := filepath.Join("testdata", "golden", fmt.Sprintf("p%v_s%v.json", gg.problem, gg.seed))
+		if *update {
+			o, _ := json.MarshalIndent(&actual, "", "  ")
+			if err := ioutil.WriteFile(path, o, 0644); err != nil {
+				t.Fatalf("can't write golden file %v: %v", path, err)
+			}
+			continue
+		}
+
+		in, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("can't open golden file %v: %v", path, err)
+		}
+		expected := golden{}
+		json.Unmarshal(in, &expected)
+
+		if r.Err != nil {
+			t.Errorf("Invalid solution for problem %v seed %v: %v", gg.problem, gg.seed, r.Err)
+		}
+		if actual != expected {
+			t.Errorf("Problem %v seed %v changed, got score %v + %v expected %v + %v (run with -update if intended)",
+				gg.problem, gg.seed, actual.MoveScore, actual.PowerScore, expected.MoveScore, expected.PowerScore)
+		}
+	}
+}
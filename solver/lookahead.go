@@ -0,0 +1,91 @@
+package solver
+
+import "strings"
+import "time"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+import "github.com/mneise/icfp15/phrases"
+
+// lookahead wraps a solver so it only ever sees the current unit and
+// o.Lookahead-1 previews: for every unit it solves the game of the board
+// so far and the units in view, and keeps where that locks the current
+// one. The moves are routed on from those played before, as in play, so
+// phrases carry across units.
+func lookahead(s Solver, o Options) Solver {
+	return SolverFunc(func(in Input) Solution {
+		start := time.Now()
+		p := in.Program
+		src := in.source()
+		h := game.NewHistory(board.NewBoard(p.Height, p.Width, p.Filled))
+		c := newCarry(in.phrases())
+		end := SourceExhausted
+
+		for {
+			if o.MaxUnits > 0 && h.Len() >= o.MaxUnits {
+				if src.Remaining() > 0 {
+					end = GaveUp
+				}
+				break
+			}
+			is := src.Peek(o.Lookahead)
+			if len(is) == 0 {
+				break
+			}
+			b := h.Board()
+			u := b.StartLocation(p.Units[is[0]])
+			if !b.IsValid(u) {
+				end = SpawnBlocked
+				break
+			}
+
+			sub := in
+			sub.Program.Filled = filledCells(b)
+			sub.Units = is
+			if in.Budget > 0 {
+				sub.Budget = (in.Budget - time.Since(start)) / time.Duration(src.Remaining())
+				if sub.Budget <= 0 {
+					sub.Budget = 1
+				}
+			}
+			t, m := firstLock(b, u, s.Solve(sub).Commands)
+			h.Lock(is[0], t, c.route(b, u, t, m, h.LastMoves(c.longest)))
+			src.Next()
+		}
+
+		return historyGame(h, in.phrases(), end).solution(start)
+	})
+}
+
+func filledCells(b board.Board) []hex.Cell {
+	cs := []hex.Cell{}
+	for y := range b {
+		for x := range b[y] {
+			if b[y][x] {
+				cs = append(cs, hex.Cell{X: x, Y: y})
+			}
+		}
+	}
+	return cs
+}
+
+// firstLock replays the commands of a solution for the unit spawned at s
+// up to the move locking it, or drops the unit if they never do.
+func firstLock(b board.Board, s hex.Unit, commands string) (hex.Unit, []hex.Move) {
+	u := s
+	ms := []hex.Move{}
+	for _, c := range strings.ToLower(commands) {
+		m, ok := phrases.CommandMoves[c]
+		if !ok {
+			continue
+		}
+		ms = append(ms, m)
+		nu := u.Move(m)
+		if !b.IsValid(nu) {
+			return u, ms
+		}
+		u = nu
+	}
+	return drop(b, s)
+}
@@ -0,0 +1,189 @@
+package solver
+
+import "testing"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+
+func TestMoveToLowerRight(t *testing.T) {
+	b := board.NewBoard(2, 3, []hex.Cell{})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 1, Y: 0}}
+	target := hex.Unit{Members: []hex.Cell{hex.Cell{X: 2, Y: 1}}, Pivot: hex.Cell{X: 2, Y: 1}}
+
+	actual := MoveSequence(b, atom, target)
+	expected := []hex.Move{hex.E, hex.SE, hex.SE}
+
+	if len(actual) != len(expected) {
+		t.Errorf("Not the same amount of moves: %v expected %v", actual, expected)
+		return
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Failed to move to lower right, got moves: %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestMoveToLowerRightWithObstacle(t *testing.T) {
+	b := board.NewBoard(2, 3, []hex.Cell{hex.Cell{X: 2, Y: 0}})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 1, Y: 0}}
+	target := hex.Unit{Members: []hex.Cell{hex.Cell{X: 2, Y: 1}}, Pivot: hex.Cell{X: 2, Y: 1}}
+
+	actual := MoveSequence(b, atom, target)
+	expected := []hex.Move{hex.SE, hex.E, hex.SE}
+
+	if len(actual) != len(expected) {
+		t.Errorf("Not the same amount of moves: %v expected %v", actual, expected)
+		return
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Failed to move to lower right, got moves: %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestNoSequencePossible(t *testing.T) {
+	b := board.NewBoard(3, 2, []hex.Cell{hex.Cell{X: 0, Y: 1}, hex.Cell{X: 1, Y: 1}})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	target := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 2}}, Pivot: hex.Cell{X: 0, Y: 2}}
+
+	actual := MoveSequence(b, atom, target)
+	expected := []hex.Move{}
+
+	if len(actual) != len(expected) {
+		t.Errorf("Not the same amount of moves: %v expected %v", actual, expected)
+		return
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Failed to move to lower right, got moves: %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestMoveToLowerLeft(t *testing.T) {
+	b := board.NewBoard(2, 3, []hex.Cell{})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 1, Y: 0}}
+	target := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 1}}, Pivot: hex.Cell{X: 0, Y: 1}}
+
+	actual := MoveSequence(b, atom, target)
+	expected := []hex.Move{hex.W, hex.SE, hex.SE}
+
+	if len(actual) != len(expected) {
+		t.Errorf("Not the same amount of moves: %v expected %v", actual, expected)
+		return
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Failed to move to lower right, got moves: %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestMoveFurtherToLowerLeft(t *testing.T) {
+	b := board.NewBoard(5, 3, []hex.Cell{})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 1, Y: 0}}
+	target := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 4}}, Pivot: hex.Cell{X: 0, Y: 4}}
+
+	actual := MoveSequence(b, atom, target)
+	expected := []hex.Move{hex.W, hex.SE, hex.SE, hex.W, hex.SE, hex.SE, hex.W, hex.SE}
+
+	if len(actual) != len(expected) {
+		t.Errorf("Not the same amount of moves: %v expected %v", actual, expected)
+		return
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Failed to move to further lower left, got moves: %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestPathFindingFailure(t *testing.T) {
+
+	b := board.NewBoard(
+		10,
+		10,
+		[]hex.Cell{
+			hex.Cell{X: 0, Y: 6},
+			hex.Cell{X: 5, Y: 6},
+			hex.Cell{X: 9, Y: 6},
+			hex.Cell{X: 0, Y: 7},
+			hex.Cell{X: 2, Y: 7},
+			hex.Cell{X: 3, Y: 7},
+			hex.Cell{X: 7, Y: 7},
+			hex.Cell{X: 8, Y: 7},
+			hex.Cell{X: 1, Y: 8},
+			hex.Cell{X: 2, Y: 8},
+			hex.Cell{X: 3, Y: 8},
+			hex.Cell{X: 5, Y: 8},
+			hex.Cell{X: 6, Y: 8},
+			hex.Cell{X: 8, Y: 8},
+			hex.Cell{X: 9, Y: 8},
+			hex.Cell{X: 1, Y: 9},
+			hex.Cell{X: 2, Y: 9},
+			hex.Cell{X: 3, Y: 9},
+			hex.Cell{X: 4, Y: 9},
+			hex.Cell{X: 6, Y: 9},
+			hex.Cell{X: 7, Y: 9},
+			hex.Cell{X: 8, Y: 9},
+			hex.Cell{X: 9, Y: 9},
+		},
+	)
+
+	sl := hex.Unit{Members: []hex.Cell{hex.Cell{X: 4, Y: 0}, hex.Cell{X: 4, Y: 2}}, Pivot: hex.Cell{X: 4, Y: 1}}
+	tl := hex.Unit{Members: []hex.Cell{hex.Cell{X: 7, Y: 6}, hex.Cell{X: 7, Y: 8}}, Pivot: hex.Cell{X: 7, Y: 7}}
+
+	ms := MoveSequence(b, sl, tl)
+
+	expected := []hex.Move{hex.E, hex.E, hex.E, hex.SE, hex.W, hex.SE, hex.SE, hex.W, hex.SE, hex.SW, hex.SE, hex.SE}
+
+	for i, em := range expected {
+		if ms[i] != em {
+			t.Errorf("did not find proper path. got %v expected %v", ms, expected)
+		}
+	}
+}
+
+func TestDrop(t *testing.T) {
+	b := board.NewBoard(4, 3, []hex.Cell{})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 1, Y: 0}}
+
+	actualUnit, actual := drop(b, atom)
+	expected := []hex.Move{hex.SE, hex.SW, hex.SE, hex.SE}
+
+	if len(actual) != len(expected) || actualUnit.Pivot != (hex.Cell{X: 1, Y: 3}) {
+		t.Errorf("Failed to drop straight down, got moves: %v to %v expected %v", actual, actualUnit, expected)
+		return
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Failed to drop straight down, got moves: %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestRouteFinishesPhrase(t *testing.T) {
+	b := board.NewBoard(6, 5, []hex.Cell{})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 2, Y: 0}}, Pivot: hex.Cell{X: 2, Y: 0}}
+	target := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 5}}, Pivot: hex.Cell{X: 0, Y: 5}}
+	c := newCarry(map[string]string{"bap": "ei!"})
+
+	// the last unit spelled "e", the next one finishes "ei!" first
+	actual := c.route(b, atom, target, MoveSequence(b, atom, target), []hex.Move{hex.E})
+	if len(actual) < 2 || actual[0] != hex.SW || actual[1] != hex.W {
+		t.Fatalf("Expected moves to start with SW W, got: %v", actual)
+	}
+
+	u, ok := follow(b, atom, actual[:len(actual)-1], map[string]bool{})
+	if !ok || u.Position() != target.Position() || b.IsValid(u.Move(actual[len(actual)-1])) {
+		t.Errorf("Expected moves to lock the unit at %v, got: %v to %v", target, actual, u)
+	}
+}
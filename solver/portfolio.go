@@ -0,0 +1,113 @@
+package solver
+
+import "fmt"
+import "time"
+
+import "github.com/mneise/icfp15/game"
+
+// Config is a registered solver with the options it runs with.
+type Config struct {
+	Solver    string  `json:"solver"`
+	Weights   Weights `json:"weights"`
+	BeamWidth int     `json:"beamWidth"`
+	MaxUnits  int     `json:"maxUnits,omitempty"`
+	Power     float64 `json:"power,omitempty"`
+	Lookahead int     `json:"lookahead,omitempty"`
+}
+
+func (c Config) String() string {
+	s := c.Solver
+	if c.BeamWidth > 0 {
+		s += fmt.Sprintf(" width=%v", c.BeamWidth)
+	}
+	if c.MaxUnits > 0 {
+		s += fmt.Sprintf(" units=%v", c.MaxUnits)
+	}
+	if c.Power != 0 {
+		s += fmt.Sprintf(" power=%v", c.Power)
+	}
+	if c.Lookahead > 0 {
+		s += fmt.Sprintf(" lookahead=%v", c.Lookahead)
+	}
+	if c.Weights != (Weights{}) {
+		s += fmt.Sprintf(" %+v", c.Weights)
+	}
+	return s
+}
+
+func (c Config) options(debug bool) Options {
+	return Options{Debug: debug, Weights: c.Weights, BeamWidth: c.BeamWidth, MaxUnits: c.MaxUnits, Power: c.Power, Lookahead: c.Lookahead}
+}
+
+var DefaultPortfolio = []Config{
+	Config{Solver: "greedy"},
+	Config{Solver: "heuristic"},
+	Config{Solver: "beam", BeamWidth: 2},
+	Config{Solver: "beam", BeamWidth: 6},
+	Config{Solver: "beam", BeamWidth: 4, Weights: Weights{Lines: 50, Height: -2, Holes: -20, Bumpiness: -1}},
+}
+
+// Portfolio runs several configured solvers on the same seed and keeps the
+// solution game.Simulate scores highest with the input's phrases.
+type Portfolio struct {
+	Configs []Config
+	Debug   bool
+}
+
+// Solve splits the budget evenly among the configs still to run, so time
+// a fast solver leaves over goes to the later ones. It returns the best
+// solution and the config that found it, greedy's if none of the configs
+// found a valid one.
+func (p Portfolio) Solve(in Input) (Solution, Config) {
+	start := time.Now()
+	best := Solution{}
+	winner := Config{}
+	bestScore := -1
+
+	for i, c := range p.Configs {
+		s, err := New(c.Solver, c.options(p.Debug))
+		if err != nil {
+			panic(err.Error())
+		}
+
+		cin := in
+		if in.Budget > 0 {
+			left := in.Budget - time.Since(start)
+			if left <= 0 {
+				break
+			}
+			cin.Budget = left / time.Duration(len(p.Configs)-i)
+		}
+
+		sol := s.Solve(cin)
+		r := game.SimulateSource(in.Program, in.source(), sol.Commands, in.phrases())
+		if r.Err != nil {
+			continue
+		}
+		if r.Score > bestScore {
+			best, winner, bestScore = sol, c, r.Score
+			best.Stats = Stats{MoveScore: r.MoveScore, PowerScore: r.PowerScore, Placed: r.Placed, End: sol.Stats.End}
+		}
+	}
+
+	if bestScore < 0 {
+		Options{Debug: p.Debug}.logMsg("portfolio: no configuration won, falling back to greedy")
+		winner = Config{Solver: "greedy"}
+		s, _ := New(winner.Solver, winner.options(p.Debug))
+		best = s.Solve(in)
+	}
+
+	best.Stats.Elapsed = time.Since(start)
+	return best, winner
+}
+
+func init() {
+	Register("portfolio", func(o Options) Solver {
+		p := Portfolio{Configs: DefaultPortfolio, Debug: o.Debug}
+		return SolverFunc(func(in Input) Solution {
+			sol, c := p.Solve(in)
+			o.logMsg(fmt.Sprintf("portfolio: %v won with %v", c, sol.Stats.MoveScore+sol.Stats.PowerScore))
+			return sol
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package solver
+
+import "testing"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/phrases"
+
+func TestPortfolioKeepsBest(t *testing.T) {
+	p := readProgramFile(t, 0)
+	configs := []Config{
+		Config{Solver: "greedy"},
+		Config{Solver: "beam", BeamWidth: 2},
+	}
+	sol, winner := Portfolio{Configs: configs}.Solve(Input{Program: p, Seed: 0})
+
+	r := game.Simulate(p, 0, sol.Commands)
+	if r.Err != nil || r.MoveScore != sol.Stats.MoveScore {
+		t.Errorf("Portfolio solution disagrees with simulator: %+v %+v", sol.Stats, r)
+	}
+
+	for _, c := range configs {
+		s, _ := New(c.Solver, c.options(false))
+		if other := s.Solve(Input{Program: p, Seed: 0}); game.Simulate(p, 0, other.Commands).Score > r.Score {
+			t.Errorf("Portfolio picked %v with %v but %v scores more", winner, r.Score, c)
+		}
+	}
+}
+
+func TestPortfolioFallsBackToGreedy(t *testing.T) {
+	registry["broken"] = func(o Options) Solver {
+		return SolverFunc(func(in Input) Solution { return Solution{Commands: "#"} })
+	}
+	defer delete(registry, "broken")
+
+	p := readProgramFile(t, 0)
+	sol, winner := Portfolio{Configs: []Config{Config{Solver: "broken"}}}.Solve(Input{Program: p, Seed: 0})
+	greedy := Play(p, 0, Options{})
+	if winner.Solver != "greedy" || sol.Commands != greedy.Solution || sol.Stats.MoveScore != greedy.MoveScore {
+		t.Errorf("Expected greedy's solution when no config found one, got %v: %+v", winner, sol.Stats)
+	}
+}
+
+func TestPortfolioRanksWithPhrases(t *testing.T) {
+	p := readProgramFile(t, 1)
+	ps, _ := phrases.Table([]string{"lal"})
+	configs := []Config{
+		Config{Solver: "heuristic"},
+		Config{Solver: "power", Power: 10},
+	}
+	in := Input{Program: p, Seed: 0, Phrases: ps}
+	sol, winner := Portfolio{Configs: configs}.Solve(in)
+
+	best := game.SimulateWith(p, 0, sol.Commands, ps).Score
+	for _, c := range configs {
+		s, _ := New(c.Solver, c.options(false))
+		if other := game.SimulateWith(p, 0, s.Solve(in).Commands, ps).Score; other > best {
+			t.Errorf("Portfolio picked %v with %v but %v scores %v with the input's phrases", winner, best, c, other)
+		}
+	}
+}
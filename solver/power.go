@@ -0,0 +1,144 @@
+package solver
+
+import "sort"
+import "time"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+
+// DefaultPower makes a point of power score worth a tenth of a point of
+// Evaluate, as spelling phrases takes room the next units need.
+const DefaultPower = 0.1
+
+const (
+	// powerCandidates is how many of the targets Evaluate likes best the
+	// power solver tries to wander to.
+	powerCandidates = 6
+	// maxWander bounds the phrases a unit spells on its way.
+	maxWander = 20
+)
+
+// wander takes the unit spawned at s to t, first spelling as many phrases
+// as it can in open space without revisiting a position: the one the
+// tail started, then unused phrases before used ones and long before
+// short. It returns the moves, no lock move chosen yet, and the phrases
+// spelled.
+func (c carry) wander(b board.Board, s, t hex.Unit, tail []hex.Move, used map[int]bool) ([]hex.Move, []int) {
+	prefix := []hex.Move{}
+	spelled := []int{}
+
+	if _, pending, j := c.progress(c.tail(tail)); len(pending) > 0 && len(via(b, s, t, pending)) > 0 {
+		prefix = append(prefix, pending...)
+		spelled = append(spelled, j)
+	}
+
+	order := make([]int, len(c.phrases))
+	for j := range order {
+		order[j] = j
+	}
+	for n := 0; n < maxWander; n++ {
+		sort.SliceStable(order, func(a, b int) bool {
+			ua, ub := used[order[a]] || contains(spelled, order[a]), used[order[b]] || contains(spelled, order[b])
+			if ua != ub {
+				return !ua
+			}
+			return len(c.phrases[order[a]]) > len(c.phrases[order[b]])
+		})
+
+		found := false
+		for _, j := range order {
+			pm := append(append([]hex.Move{}, prefix...), c.phrases[j]...)
+			if len(via(b, s, t, pm)) > 0 {
+				prefix, spelled, found = pm, append(spelled, j), true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+	return via(b, s, t, prefix), spelled
+}
+
+// gain is the power score spelling the phrases adds.
+func (c carry) gain(spelled []int, used map[int]bool) int {
+	g := 0
+	seen := map[int]bool{}
+	for _, j := range spelled {
+		g += 2 * len(c.phrases[j])
+		if !used[j] && !seen[j] {
+			g += 300
+		}
+		seen[j] = true
+	}
+	return g
+}
+
+func contains(is []int, i int) bool {
+	for _, x := range is {
+		if x == i {
+			return true
+		}
+	}
+	return false
+}
+
+// power plays for the total score: of the targets Evaluate likes best it
+// takes the one rating best with the power score of the phrases the unit
+// can spell on its way there weighed in by o.Power. Once the budget is
+// spent it takes the best target and spells on the way only.
+func power(in Input, o Options) Solution {
+	start := time.Now()
+	p := in.Program
+	c := newCarry(in.phrases())
+	used := map[int]bool{}
+	h := game.NewHistory(board.NewBoard(p.Height, p.Width, p.Filled))
+	is, end := o.units(in.source())
+
+	for _, i := range is {
+		b := h.Board()
+		s := b.StartLocation(p.Units[i])
+		if !b.IsValid(s) {
+			end = SpawnBlocked
+			break
+		}
+
+		ps := o.rankedTargets(b, s)
+		if in.Budget > 0 && time.Since(start) > in.Budget {
+			ps = ps[:1]
+		} else if len(ps) > powerCandidates {
+			ps = ps[:powerCandidates]
+		}
+
+		tail := h.LastMoves(c.longest)
+		best, bestMoves, bestSpelled, bestValue := hex.Unit{}, []hex.Move(nil), []int(nil), 0.0
+		for _, pl := range ps {
+			m, spelled := c.wander(b, s, pl.t, tail, used)
+			if len(m) == 0 {
+				m, spelled = pl.m, nil
+			}
+			v := pl.value + o.power()*float64(c.gain(spelled, used))
+			if bestMoves == nil || v > bestValue {
+				best, bestMoves, bestSpelled, bestValue = pl.t, m, spelled, v
+			}
+		}
+
+		for _, j := range bestSpelled {
+			used[j] = true
+		}
+		h.Lock(i, best, c.lock(b, best, bestMoves, tail))
+	}
+
+	return historyGame(h, in.phrases(), end).solution(start)
+}
+
+func init() {
+	// power trades lines for phrases of power, see Options.Power.
+	Register("power", func(o Options) Solver {
+		return SolverFunc(func(in Input) Solution {
+			return power(in, o)
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package solver
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+
+// Reachable flood fills the pivots the unit spawned at s can be moved to
+// without turning it, which covers everywhere MoveSequence takes it.
+func Reachable(b board.Board, s hex.Unit) map[hex.Cell]bool {
+	sh := newShape(s)
+	seen := map[hex.Cell]bool{s.Pivot: true}
+	todo := []hex.Cell{s.Pivot}
+	for len(todo) > 0 {
+		p := todo[len(todo)-1]
+		todo = todo[:len(todo)-1]
+		for _, m := range []hex.Move{hex.SE, hex.SW, hex.E, hex.W} {
+			if n := p.Move(m); !seen[n] && sh.fits(b, n) {
+				seen[n] = true
+				todo = append(todo, n)
+			}
+		}
+	}
+	return seen
+}
+
+// ReachableTargets iterates the TargetLocations of the unit spawned at s
+// it can reach, in the same order. Enclosed ones MoveSequence would only
+// find no way to are left out up front.
+func ReachableTargets(b board.Board, s hex.Unit) *Targets {
+	reach := Reachable(b, s)
+	return NewTargets(b, s, func(p hex.Cell) bool { return reach[p] })
+}
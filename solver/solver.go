@@ -0,0 +1,315 @@
+// Package solver plays games: it picks a target location for every unit
+// and finds the moves that take the unit there and lock it.
+package solver
+
+import "fmt"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+import "github.com/mneise/icfp15/phrases"
+
+// Options tweak how a game is played.
+type Options struct {
+	Debug     bool
+	Weights   Weights
+	BeamWidth int
+	// MaxUnits stops the game after placing that many units, zero means
+	// playing until the source is exhausted.
+	MaxUnits int
+	// Power weighs the power score the power solver expects from a target
+	// against what Evaluate makes of it, zero means DefaultPower.
+	Power float64
+	// Lookahead hides all but the current unit and Lookahead-1 previews
+	// from the solver, zero shows it the whole source.
+	Lookahead int
+}
+
+func (o Options) power() float64 {
+	if o.Power == 0 {
+		return DefaultPower
+	}
+	return o.Power
+}
+
+// units takes the unit indexes of a game from the source, at most
+// o.MaxUnits. It also tells how a game placing all of them ends.
+func (o Options) units(src game.UnitSource) ([]int, End) {
+	if o.MaxUnits > 0 && src.Remaining() > o.MaxUnits {
+		return src.Peek(o.MaxUnits), GaveUp
+	}
+	return game.Drain(src), SourceExhausted
+}
+
+func (o Options) weights() Weights {
+	if o.Weights == (Weights{}) {
+		return DefaultWeights
+	}
+	return o.Weights
+}
+
+func (o Options) logBoard(m string, b board.Board) {
+	if o.Debug {
+		fmt.Printf("%v:\n%v\n", m, b)
+	}
+}
+
+func (o Options) logMsg(m string) {
+	if o.Debug {
+		fmt.Printf("%v\n", m)
+	}
+}
+
+// End tells why a game ended.
+type End int
+
+const (
+	// SourceExhausted means every unit of the source was placed.
+	SourceExhausted End = iota
+	// SpawnBlocked means the next unit's start location isn't valid.
+	SpawnBlocked
+	// GaveUp means the solver stopped before the source ran out.
+	GaveUp
+)
+
+func (e End) String() string {
+	switch e {
+	case SourceExhausted:
+		return "source exhausted"
+	case SpawnBlocked:
+		return "spawn blocked"
+	case GaveUp:
+		return "gave up"
+	}
+	return fmt.Sprintf("End(%d)", int(e))
+}
+
+// Game is the outcome of playing one seed of a program.
+type Game struct {
+	Solution   string
+	MoveScore  int
+	PowerScore int
+	Placed     int
+	End        End
+}
+
+func (g Game) Score() int {
+	return g.MoveScore + g.PowerScore
+}
+
+// chooser picks the location the unit spawned at s locks in and the moves
+// taking it there. No moves means it found none, the unit is dropped then.
+type chooser func(b board.Board, s hex.Unit) (hex.Unit, []hex.Move)
+
+// Play places the units of one seed in order, each at the first target
+// location that MoveSequence can reach, until a unit can't spawn.
+func Play(p game.Program, seed int, o Options) Game {
+	return play(p, p.Source(seed), phrases.PowerPhrases, o, o.firstTarget)
+}
+
+func play(p game.Program, src game.UnitSource, ps map[string]string, o Options, choose chooser) Game {
+	h := game.NewHistory(board.NewBoard(p.Height, p.Width, p.Filled))
+
+	is, end := o.units(src)
+	c := newCarry(ps)
+
+	for count, i := range is {
+		b := h.Board()
+		u := p.Units[i]
+		s := b.StartLocation(u)
+		if !b.IsValid(s) {
+			o.logMsg(fmt.Sprintf("couldn't place unit %v %v! GAME OVER BABY", count+1, u))
+			end = SpawnBlocked
+			break
+		}
+
+		o.logMsg("======================================================")
+		o.logBoard(fmt.Sprintf("trying to place unit %v (%vth) on board", u, count+1), b.FillCells(s.Members))
+
+		t, m := choose(b, s)
+		if len(m) == 0 {
+			o.logMsg(fmt.Sprintf("found no moves, dropping the unit"))
+			t, m = drop(b, s)
+		}
+
+		m = c.route(b, s, t, m, h.LastMoves(c.longest))
+		o.logMsg(fmt.Sprintf("found moves: %v", m))
+
+		st := h.Lock(i, t, m)
+		o.logBoard(fmt.Sprintf("unit %v placed on board", i), b.FillCells(t.Members))
+		if st.Cleared > 0 {
+			o.logBoard(fmt.Sprintf("cleared full rows"), st.Board)
+		}
+	}
+
+	return historyGame(h, ps, end)
+}
+
+// historyGame spells the moves of the steps in effect with as many
+// phrases of power as they allow.
+func historyGame(h *game.History, ps map[string]string, end End) Game {
+	g := Game{MoveScore: h.MoveScore(), Placed: h.Len(), End: end}
+	g.Solution = phrases.Assign(h.Moves(), ps)
+	g.PowerScore = phrases.Score(g.Solution, ps)
+	return g
+}
+
+func (o Options) firstTarget(b board.Board, s hex.Unit) (hex.Unit, []hex.Move) {
+	ts := ReachableTargets(b, s)
+	for t, ok := ts.Next(); ok; t, ok = ts.Next() {
+		if m := MoveSequence(b, s, t); len(m) > 0 {
+			return t, m
+		}
+		o.logMsg(fmt.Sprintf("found no moves for target %v", t))
+	}
+	return hex.Unit{}, []hex.Move{}
+}
+
+// bestTarget picks the reachable target whose board Evaluate rates best.
+func (o Options) bestTarget(b board.Board, s hex.Unit) (hex.Unit, []hex.Move) {
+	best := hex.Unit{}
+	bestMoves := []hex.Move{}
+	bestValue := 0.0
+	ts := ReachableTargets(b, s)
+	for t, ok := ts.Next(); ok; t, ok = ts.Next() {
+		m := MoveSequence(b, s, t)
+		if len(m) == 0 {
+			continue
+		}
+		nb, cleared := b.FillCells(t.Members).ClearFullRows()
+		if v := Evaluate(nb, cleared, o.weights()); len(bestMoves) == 0 || v > bestValue {
+			best, bestMoves, bestValue = t, m, v
+		}
+	}
+	return best, bestMoves
+}
+
+// drop moves the unit straight down, alternating SE and SW where it can,
+// as far as it goes and locks it there. Any unit that spawned can be
+// dropped, and moving down never revisits a position.
+func drop(b board.Board, s hex.Unit) (hex.Unit, []hex.Move) {
+	u := s
+	ms := []hex.Move{}
+	order := []hex.Move{hex.SE, hex.SW}
+	for {
+		moved := false
+		for _, m := range order {
+			if nu := u.Move(m); b.IsValid(nu) {
+				u = nu
+				ms = append(ms, m)
+				order[0], order[1] = order[1], order[0]
+				moved = true
+				break
+			}
+		}
+		if !moved {
+			return u, append(ms, hex.SE)
+		}
+	}
+}
+
+// TargetLocations lists every valid location of the unit on the board,
+// those completing the most rows first.
+func TargetLocations(b board.Board, u hex.Unit) []hex.Unit {
+	return NewTargets(b, u, nil).All()
+}
+
+func direction(s, t hex.Cell) (xd, yd int) {
+	yd = t.Y - s.Y
+	// ys = yd
+	// if ys < 0 {
+	// 	ys = -ys
+	// }
+
+	xd = t.X - s.X
+	// xs = xd
+	// if xs < 0 {
+	// 	xs = -xs
+	// }
+
+	return xd, yd //, xs, ys
+}
+
+func moves(xd, yd int) []hex.Move {
+	// neg - left
+	// pos - right
+	// zero - down
+
+	switch {
+	case xd < 0 && yd > 0: // left down
+		return []hex.Move{hex.W, hex.SW, hex.SE, hex.E}
+	case xd == 0 && yd > 0: // down
+		return []hex.Move{hex.SE, hex.SW, hex.E, hex.W}
+	case xd > 0 && yd > 0: // right down
+		return []hex.Move{hex.E, hex.SE, hex.SW, hex.W}
+	case xd < 0 && yd == 0: // left
+		return []hex.Move{hex.W}
+	case xd > 0 && yd == 0: // right
+		return []hex.Move{hex.E}
+	case xd == 0 && yd == 0: // done
+	case yd < 0: // cant move up
+	}
+
+	return []hex.Move{}
+}
+
+// MoveSequence greedily walks the unit from s towards the target t and
+// appends a move locking it there. It returns no moves if t can't be
+// reached.
+func MoveSequence(b board.Board, s hex.Unit, t hex.Unit) []hex.Move {
+	// fmt.Printf("move from %v to %v\n", s.Pivot, t.Pivot)
+	mu := s
+	mp := s.Pivot
+	xd, yd := direction(s.Pivot, t.Pivot)
+	ms := []hex.Move{}
+
+	for true {
+		before := len(ms)
+		// fmt.Printf("main loop mp %v xd %v yd %v ms %v\n", mp, xd, yd, ms)
+
+		for _, m := range moves(xd, yd) {
+			if len(ms) > 0 &&
+				((m == hex.W && ms[len(ms)-1] == hex.E) || (m == hex.E && ms[len(ms)-1] == hex.W)) {
+				// fmt.Printf("not going backwards ms %v m %v\n", ms, m)
+				continue
+			}
+
+			// fmt.Printf("found move %v\n", m)
+			// try to move pivot / unit
+			tp := mp.Move(m)
+			tu := mu.Move(m)
+			if b.IsValid(tu) { // found valid one,yay!
+				mu = tu
+				mp = tp
+				xd, yd = direction(mp, t.Pivot)
+				ms = append(ms, m)
+				break
+			}
+			// fmt.Printf("move %v is invalid. cannot move %v to %v, trying next.\n", m, mu, tu)
+			// fmt.Printf("move %v is invalid. cannot move %v to %v, trying next.\n", m, mu, tu)
+		}
+
+		if before == len(ms) {
+			break // couldnt find move, skip out
+		}
+	}
+
+	if mp.X == t.Pivot.X && mp.Y == t.Pivot.Y {
+		// TODO: improve this.
+		switch {
+		case !b.IsValid(t.Move(hex.SE)):
+			return append(ms, hex.SE)
+		case !b.IsValid(t.Move(hex.SW)):
+			return append(ms, hex.SW)
+		case !b.IsValid(t.Move(hex.E)):
+			return append(ms, hex.E)
+		case !b.IsValid(t.Move(hex.W)):
+			return append(ms, hex.W)
+		}
+		// if !t.Move(SE).isValid(b) {
+		// 	return append(ms, SE) // lock in move
+		// }
+	}
+
+	return []hex.Move{} // can't find a legal way
+}
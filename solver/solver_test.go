@@ -0,0 +1,58 @@
+package solver
+
+import "encoding/json"
+import "math/rand"
+import "testing"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+
+func TestPlayGeneratedPrograms(t *testing.T) {
+	gp := game.GenParams{
+		Id: 3, Width: 8, Height: 12, Density: 0.3, Units: 10,
+		MinUnitSize: 2, MaxUnitSize: 5, PivotOutside: 0.5, Seeds: 3, SourceLength: 30,
+	}
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20; i++ {
+		p, err := game.GenerateProgram(r, gp)
+		if err != nil {
+			t.Fatalf("Failed to generate a program: %v", err)
+		}
+		for _, seed := range p.SourceSeeds {
+			g := Play(p, seed, Options{})
+			if r := game.Simulate(p, seed, g.Solution); r.Err != nil || r.Score != g.Score() {
+				o, _ := json.Marshal(&p)
+				t.Errorf("Solver disagrees with simulator on %s seed %v: %+v %+v", o, seed, g, r)
+			}
+		}
+	}
+}
+
+func TestGameEnd(t *testing.T) {
+	domino := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	blocked := game.Program{Width: 3, Height: 1, Units: []hex.Unit{domino}, SourceLength: 5}
+	p := readProgramFile(t, 1)
+
+	data := []struct {
+		p        game.Program
+		o        Options
+		placed   int
+		expected End
+	}{
+		{blocked, Options{}, 1, SpawnBlocked},
+		{p, Options{MaxUnits: 3}, 3, GaveUp},
+		{p, Options{}, p.SourceLength, SourceExhausted},
+	}
+
+	for _, d := range data {
+		for _, name := range []string{"greedy", "beam"} {
+			s, _ := New(name, d.o)
+			sol := s.Solve(Input{Program: d.p, Seed: 0})
+			if sol.Stats.End != d.expected || sol.Stats.Placed != d.placed {
+				t.Errorf("Expected %v to end with %v after %v units, got %v after %v",
+					name, d.expected, d.placed, sol.Stats.End, sol.Stats.Placed)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package solver
+
+import "fmt"
+import "sort"
+import "time"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/phrases"
+
+// Input is everything a solver gets to play one seed.
+type Input struct {
+	Program game.Program
+	Seed    int
+	// Phrases maps the default spelling of each phrase's moves to the
+	// phrase, nil means phrases.PowerPhrases.
+	Phrases map[string]string
+	// Budget is the time a solver may spend, zero means no limit.
+	Budget time.Duration
+	// Units are the unit indexes to play instead of the seed's, to try
+	// other sources than the contest's.
+	Units []int
+}
+
+type Stats struct {
+	MoveScore  int
+	PowerScore int
+	Placed     int
+	End        End
+	Elapsed    time.Duration
+}
+
+type Solution struct {
+	Commands string
+	Stats    Stats
+}
+
+// Solver is a strategy for playing a game.
+type Solver interface {
+	Solve(in Input) Solution
+}
+
+// SolverFunc adapts a function to the Solver interface.
+type SolverFunc func(in Input) Solution
+
+func (f SolverFunc) Solve(in Input) Solution {
+	return f(in)
+}
+
+var registry = map[string]func(o Options) Solver{}
+
+// Register makes a solver available by name to New.
+func Register(name string, f func(o Options) Solver) {
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("solver %v registered twice", name))
+	}
+	registry[name] = f
+}
+
+// New returns the solver registered under name.
+func New(name string, o Options) (Solver, error) {
+	f, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown solver %v, have %v", name, Names())
+	}
+	if o.Lookahead > 0 {
+		return lookahead(f(o), o), nil
+	}
+	return f(o), nil
+}
+
+// Names lists the registered solvers.
+func Names() []string {
+	ns := []string{}
+	for n := range registry {
+		ns = append(ns, n)
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+func (in Input) phrases() map[string]string {
+	if in.Phrases == nil {
+		return phrases.PowerPhrases
+	}
+	return in.Phrases
+}
+
+// source hands out the units to play, by default those of the seed.
+func (in Input) source() game.UnitSource {
+	if in.Units != nil {
+		return game.NewFixedSource(in.Units)
+	}
+	return in.Program.Source(in.Seed)
+}
+
+func (g Game) solution(start time.Time) Solution {
+	return Solution{
+		Commands: g.Solution,
+		Stats: Stats{
+			MoveScore:  g.MoveScore,
+			PowerScore: g.PowerScore,
+			Placed:     g.Placed,
+			End:        g.End,
+			Elapsed:    time.Since(start),
+		},
+	}
+}
+
+// chooserSolver plays with the same loop as Play, choosing targets with
+// the chooser it gets for the options.
+func chooserSolver(o Options, choose func(o Options) chooser) Solver {
+	return SolverFunc(func(in Input) Solution {
+		start := time.Now()
+		return play(in.Program, in.source(), in.phrases(), o, choose(o)).solution(start)
+	})
+}
+
+func init() {
+	// greedy takes the first reachable target, those clearing most rows
+	// first. It's what Play does.
+	Register("greedy", func(o Options) Solver {
+		return chooserSolver(o, func(o Options) chooser { return o.firstTarget })
+	})
+	// heuristic takes the reachable target whose board Evaluate likes best.
+	Register("heuristic", func(o Options) Solver {
+		return chooserSolver(o, func(o Options) chooser { return o.bestTarget })
+	})
+	Register("beam", func(o Options) Solver {
+		return SolverFunc(func(in Input) Solution {
+			return beam(in, o)
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package solver
+
+import "testing"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+
+func TestRegistry(t *testing.T) {
+	names := Names()
+	expected := []string{"backtrack", "beam", "greedy", "heuristic", "power"}
+	for _, n := range expected {
+		found := false
+		for _, a := range names {
+			found = found || a == n
+		}
+		if !found {
+			t.Errorf("Expected solver %v to be registered, got: %v", n, names)
+		}
+	}
+
+	if _, err := New("nope", Options{}); err == nil {
+		t.Errorf("Expected unknown solver to fail")
+	}
+}
+
+func TestSolversAreVerified(t *testing.T) {
+	for _, n := range Names() {
+		s, _ := New(n, Options{})
+		for _, problem := range []int{0, 6} {
+			p := readProgramFile(t, problem)
+			seed := p.SourceSeeds[0]
+			sol := s.Solve(Input{Program: p, Seed: seed})
+			r := game.Simulate(p, seed, sol.Commands)
+			if r.Err != nil || r.MoveScore != sol.Stats.MoveScore ||
+				r.PowerScore != sol.Stats.PowerScore || r.Placed != sol.Stats.Placed {
+				t.Errorf("Solver %v disagrees with simulator on problem %v: %+v %+v", n, problem, sol.Stats, r)
+			}
+		}
+	}
+}
+
+func TestGreedySolverPlays(t *testing.T) {
+	p := readProgramFile(t, 3)
+	s, _ := New("greedy", Options{})
+	sol := s.Solve(Input{Program: p, Seed: 6876})
+	g := Play(p, 6876, Options{})
+
+	if sol.Commands != g.Solution || sol.Stats.MoveScore != g.MoveScore {
+		t.Errorf("Expected greedy solver to play like Play, got: %+v expected %+v", sol.Stats, g)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	w := Weights{Lines: 1, Height: 10, Holes: 100, Bumpiness: 1000, Dead: 10000}
+	data := []struct {
+		filled   []hex.Cell
+		cleared  int
+		expected float64
+	}{
+		{filled: []hex.Cell{}, cleared: 2, expected: 2},
+		// one column of height 1 next to two empty ones
+		{filled: []hex.Cell{hex.Cell{X: 1, Y: 2}}, expected: 10 + 2000},
+		// the whole bottom row is covered by the middle one, and dead
+		{
+			filled:   []hex.Cell{hex.Cell{X: 0, Y: 1}, hex.Cell{X: 1, Y: 1}, hex.Cell{X: 2, Y: 1}},
+			expected: 60 + 300 + 30000,
+		},
+	}
+
+	for _, d := range data {
+		b := board.NewBoard(3, 3, d.filled)
+		if actual := Evaluate(b, d.cleared, w); actual != d.expected {
+			t.Errorf("Failed to evaluate board:\n%v\ngot %v expected %v", b, actual, d.expected)
+		}
+	}
+}
+
+func TestBacktrackBeatsHeuristic(t *testing.T) {
+	p := readProgramFile(t, 6)
+	h, _ := New("heuristic", Options{})
+	b, _ := New("backtrack", Options{})
+
+	hs := h.Solve(Input{Program: p, Seed: 0})
+	bs := b.Solve(Input{Program: p, Seed: 0})
+	if bs.Stats.MoveScore < hs.Stats.MoveScore {
+		t.Errorf("Expected backtracking to keep at least the heuristic's move score, got %v < %v",
+			bs.Stats.MoveScore, hs.Stats.MoveScore)
+	}
+}
+
+func TestPowerSpellsMorePhrases(t *testing.T) {
+	p := readProgramFile(t, 6)
+	h, _ := New("heuristic", Options{})
+	pw, _ := New("power", Options{})
+
+	hs := h.Solve(Input{Program: p, Seed: 0})
+	ps := pw.Solve(Input{Program: p, Seed: 0})
+	if ps.Stats.PowerScore <= hs.Stats.PowerScore {
+		t.Errorf("Expected power solver to spell more phrases, got %v <= %v",
+			ps.Stats.PowerScore, hs.Stats.PowerScore)
+	}
+	if r := game.Simulate(p, 0, ps.Commands); r.Err != nil || r.Score != ps.Stats.MoveScore+ps.Stats.PowerScore {
+		t.Errorf("Expected power solution to score as it says, got %+v for %+v", r, ps.Stats)
+	}
+}
+
+func TestLookaheadHidesUnits(t *testing.T) {
+	p := readProgramFile(t, 3)
+	greedy, _ := New("greedy", Options{})
+	seen := 0
+	spy := SolverFunc(func(in Input) Solution {
+		if len(in.Units) > seen {
+			seen = len(in.Units)
+		}
+		return greedy.Solve(in)
+	})
+
+	sol := lookahead(spy, Options{Lookahead: 2}).Solve(Input{Program: p, Seed: 6876})
+	if seen != 2 {
+		t.Errorf("Expected the solver to see 2 units at a time, but saw %v", seen)
+	}
+	// greedy only ever looks at the current unit anyway
+	if g := Play(p, 6876, Options{}); sol.Stats.MoveScore != g.MoveScore || sol.Stats.Placed != g.Placed {
+		t.Errorf("Expected greedy to play the same without lookahead, got %+v expected %+v", sol.Stats, g)
+	}
+}
+
+func TestLookaheadIsVerified(t *testing.T) {
+	p := readProgramFile(t, 6)
+	for _, n := range Names() {
+		s, _ := New(n, Options{Lookahead: 2, MaxUnits: 30})
+		sol := s.Solve(Input{Program: p, Seed: 0})
+		r := game.Simulate(p, 0, sol.Commands)
+		if r.Err != nil || r.Score != sol.Stats.MoveScore+sol.Stats.PowerScore || r.Placed != sol.Stats.Placed ||
+			r.Placed > 30 || r.Placed == 30 && sol.Stats.End != GaveUp {
+			t.Errorf("Solver %v disagrees with simulator without lookahead: %+v %+v", n, sol.Stats, r)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package solver
+
+import "fmt"
+import "math/rand"
+import "testing"
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/hex"
+
+func equalsUnit(actual hex.Unit, expected hex.Unit) bool {
+	if actual.Height() != expected.Height() ||
+		actual.Width() != expected.Width() {
+		return false
+	}
+
+	for i := range expected.Members {
+		if actual.Members[i] != expected.Members[i] {
+			return false
+		}
+	}
+
+	if expected.Pivot != actual.Pivot {
+		return false
+	}
+
+	return true
+}
+
+func TestFindTargetLowerRight(t *testing.T) {
+	b := board.NewBoard(2, 2, []hex.Cell{hex.Cell{X: 1, Y: 0}})
+	unit := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	actual := TargetLocations(b, unit)
+	expected := []hex.Unit{
+		hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}},
+		hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 1}}, Pivot: hex.Cell{X: 1, Y: 1}},
+		hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 1}}, Pivot: hex.Cell{X: 0, Y: 1}}}
+
+	if len(expected) != len(actual) {
+		t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+	}
+
+	for i := range expected {
+		if !equalsUnit(actual[i], expected[i]) {
+			t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+		}
+	}
+
+	b = board.NewBoard(2, 2, []hex.Cell{
+		hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0},
+		hex.Cell{X: 1, Y: 1}})
+	unit = hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	actual = TargetLocations(b, unit)
+	expected = []hex.Unit{hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 1}}, Pivot: hex.Cell{X: 0, Y: 1}}}
+
+	if len(expected) != len(actual) {
+		t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+	}
+
+	for i := range expected {
+		if !equalsUnit(actual[i], expected[i]) {
+			t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+		}
+	}
+
+	b = board.NewBoard(3, 3, []hex.Cell{
+		hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}, hex.Cell{X: 2, Y: 0},
+		hex.Cell{X: 0, Y: 1}, hex.Cell{X: 2, Y: 1},
+		hex.Cell{X: 0, Y: 2}, hex.Cell{X: 1, Y: 2}, hex.Cell{X: 2, Y: 2}})
+	unit = hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	actual = TargetLocations(b, unit)
+	expected = []hex.Unit{
+		hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 1}}, Pivot: hex.Cell{X: 1, Y: 1}}}
+
+	if len(expected) != len(actual) {
+		t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+	}
+
+	for i := range expected {
+		if !equalsUnit(actual[i], expected[i]) {
+			t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+		}
+	}
+
+	b = board.NewBoard(3, 3, []hex.Cell{
+		hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}, hex.Cell{X: 2, Y: 0},
+		hex.Cell{X: 0, Y: 1},
+		hex.Cell{X: 0, Y: 2}, hex.Cell{X: 1, Y: 2}})
+	unit = hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	actual = TargetLocations(b, unit)
+	expected = []hex.Unit{
+		hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 1}, hex.Cell{X: 2, Y: 1}}, Pivot: hex.Cell{X: 1, Y: 1}}}
+
+	if len(expected) != len(actual) {
+		t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+	}
+
+	for i := range expected {
+		if !equalsUnit(actual[i], expected[i]) {
+			t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+		}
+	}
+
+	b = board.NewBoard(3, 3, []hex.Cell{
+		hex.Cell{X: 0, Y: 0}, hex.Cell{X: 1, Y: 0}, hex.Cell{X: 2, Y: 0},
+		hex.Cell{X: 0, Y: 1},
+		hex.Cell{X: 0, Y: 2}, hex.Cell{X: 1, Y: 2}})
+	unit = hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	actual = TargetLocations(b, unit)
+	expected = []hex.Unit{
+		hex.Unit{Members: []hex.Cell{hex.Cell{X: 2, Y: 2}}, Pivot: hex.Cell{X: 2, Y: 2}},
+		hex.Unit{Members: []hex.Cell{hex.Cell{X: 2, Y: 1}}, Pivot: hex.Cell{X: 2, Y: 1}},
+		hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 1}}, Pivot: hex.Cell{X: 1, Y: 1}}}
+
+	if len(expected) != len(actual) {
+		t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+	}
+
+	for i := range expected {
+		if !equalsUnit(actual[i], expected[i]) {
+			t.Errorf("Failed to find target, got unit: %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestReachableTargets(t *testing.T) {
+	// the pocket at the bottom right is walled in
+	b := board.NewBoard(3, 3, []hex.Cell{
+		hex.Cell{X: 1, Y: 1}, hex.Cell{X: 2, Y: 1},
+		hex.Cell{X: 1, Y: 2}})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 1, Y: 0}}, Pivot: hex.Cell{X: 1, Y: 0}}
+
+	all := TargetLocations(b, atom)
+	actual := ReachableTargets(b, atom).All()
+	if len(actual) != len(all)-1 {
+		t.Errorf("Expected all targets but the pocket, got %v of %v", actual, all)
+	}
+	for _, u := range actual {
+		if u.Pivot == (hex.Cell{X: 2, Y: 2}) {
+			t.Errorf("Expected the pocket to be left out, got %v", actual)
+		}
+	}
+}
+
+// fillCellsTargets is how TargetLocations used to rank locations, filling
+// a copy of the board for each.
+func fillCellsTargets(b board.Board, u hex.Unit) []hex.Unit {
+	bu := make([][]hex.Unit, b.Height()+1)
+	for y := range b {
+		for x := range b[y] {
+			t := u.MoveTo(hex.Cell{X: x, Y: y}, u.Pivot)
+			if b.IsValid(t) {
+				c := b.FillCells(t.Members).CountFullRows()
+				bu[c] = append([]hex.Unit{t}, bu[c]...)
+			}
+		}
+	}
+	ts := []hex.Unit{}
+	for i := len(bu) - 1; i >= 0; i-- {
+		ts = append(ts, bu[i]...)
+	}
+	return ts
+}
+
+// almostFull fills the lower half of the problem's board but for a few
+// cells a row, so units complete rows in many places.
+func almostFull(p game.Program) board.Board {
+	r := rand.New(rand.NewSource(int64(p.Id)))
+	cells := append([]hex.Cell{}, p.Filled...)
+	for y := p.Height / 2; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			if r.Intn(p.Width) > 1 {
+				cells = append(cells, hex.Cell{X: x, Y: y})
+			}
+		}
+	}
+	return board.NewBoard(p.Height, p.Width, cells)
+}
+
+func TestTargetsOrder(t *testing.T) {
+	for _, problem := range []int{0, 6, 14, 24} {
+		p := readProgramFile(t, problem)
+		b := almostFull(p)
+		for i, u := range p.Units {
+			s := b.StartLocation(u)
+			actual := TargetLocations(b, s)
+			expected := fillCellsTargets(b, s)
+			if len(actual) != len(expected) {
+				t.Fatalf("problem %v unit %v: expected %v targets, but was: %v", problem, i, len(expected), len(actual))
+			}
+			for j := range expected {
+				if !equalsUnit(actual[j], expected[j]) {
+					t.Fatalf("problem %v unit %v: expected target %v to be %v, but was: %v", problem, i, j, expected[j], actual[j])
+				}
+			}
+		}
+	}
+}
+
+func TestTargetsNext(t *testing.T) {
+	b := board.NewBoard(2, 2, []hex.Cell{hex.Cell{X: 1, Y: 1}})
+	atom := hex.Unit{Members: []hex.Cell{hex.Cell{X: 0, Y: 0}}, Pivot: hex.Cell{X: 0, Y: 0}}
+	ts := NewTargets(b, atom, nil)
+
+	// completing the bottom row first, then the top row right to left
+	for _, c := range []hex.Cell{hex.Cell{X: 0, Y: 1}, hex.Cell{X: 1, Y: 0}, hex.Cell{X: 0, Y: 0}} {
+		u, ok := ts.Next()
+		if !ok || u.Pivot != c {
+			t.Errorf("Expected target at %v, but was: %v, %v", c, u, ok)
+		}
+	}
+	if u, ok := ts.Next(); ok {
+		t.Errorf("Expected no more targets, but was: %v", u)
+	}
+}
+
+func benchmarkTargets(b *testing.B, targets func(board.Board, hex.Unit) []hex.Unit) {
+	for _, problem := range []int{14, 24} {
+		p := readProgramFile(b, problem)
+		bd := almostFull(p)
+		b.Run(fmt.Sprintf("p%v", problem), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				u := p.Units[i%len(p.Units)]
+				targets(bd, bd.StartLocation(u))
+			}
+		})
+	}
+}
+
+func BenchmarkTargetLocations(b *testing.B) {
+	benchmarkTargets(b, TargetLocations)
+}
+
+func BenchmarkTargetLocationsFillCells(b *testing.B) {
+	benchmarkTargets(b, fillCellsTargets)
+}
+
+func BenchmarkFirstTarget(b *testing.B) {
+	benchmarkTargets(b, func(bd board.Board, s hex.Unit) []hex.Unit {
+		t, _ := Options{}.firstTarget(bd, s)
+		return []hex.Unit{t}
+	})
+}
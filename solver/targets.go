@@ -0,0 +1,118 @@
+package solver
+
+import "github.com/mneise/icfp15/board"
+import "github.com/mneise/icfp15/hex"
+
+// shape is a unit's members as cube offsets from its pivot, so checking a
+// location doesn't need to translate the unit.
+type shape []hex.Cube
+
+func newShape(u hex.Unit) shape {
+	pc := u.Pivot.Cube()
+	sh := shape{}
+	for _, m := range u.Members {
+		sh = append(sh, m.Cube().Sub(pc))
+	}
+	return sh
+}
+
+// fits reports whether the unit with its pivot at p is valid on the board.
+func (sh shape) fits(b board.Board, p hex.Cell) bool {
+	c := p.Cube()
+	for _, o := range sh {
+		if !b.IsValidCell(c.Add(o).Cell()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Targets iterates the valid locations of a unit on a board, those
+// completing the most rows first. Within a row count later pivots in
+// reading order come first, as TargetLocations always listed them.
+//
+// Only the pivots are ranked up front: a location completes a row when
+// the unit covers as many of its cells as are empty, so counting the
+// unit's cells per row is enough and the board is never copied. Units are
+// translated as Next hands them out, so stopping early is cheap.
+type Targets struct {
+	u       hex.Unit
+	buckets [][]hex.Cell
+	c, i    int
+}
+
+// NewTargets ranks the pivots keep likes, nil keeps all of them.
+func NewTargets(b board.Board, u hex.Unit, keep func(hex.Cell) bool) *Targets {
+	sh := newShape(u)
+	// rows the unit touches relative to its pivot and how many cells it
+	// has in each
+	drows, counts := []int{}, []int{}
+	for _, o := range sh {
+		found := false
+		for i, d := range drows {
+			if d == o.Z {
+				counts[i]++
+				found = true
+			}
+		}
+		if !found {
+			drows = append(drows, o.Z)
+			counts = append(counts, 1)
+		}
+	}
+
+	empty := make([]int, b.Height())
+	full := 0
+	for y := range b {
+		for _, f := range b[y] {
+			if !f {
+				empty[y]++
+			}
+		}
+		if empty[y] == 0 {
+			full++
+		}
+	}
+
+	ts := &Targets{u: u, buckets: make([][]hex.Cell, b.Height()+1)}
+	for y := range b {
+		for x := range b[y] {
+			p := hex.Cell{X: x, Y: y}
+			if keep != nil && !keep(p) || !sh.fits(b, p) {
+				continue
+			}
+			c := full
+			for i, d := range drows {
+				if empty[y+d] == counts[i] {
+					c++
+				}
+			}
+			ts.buckets[c] = append(ts.buckets[c], p)
+		}
+	}
+	ts.c = len(ts.buckets) - 1
+	ts.i = len(ts.buckets[ts.c])
+	return ts
+}
+
+// Next returns the next location, or false once there are none left.
+func (ts *Targets) Next() (hex.Unit, bool) {
+	for ts.i == 0 {
+		if ts.c == 0 {
+			return hex.Unit{}, false
+		}
+		ts.c--
+		ts.i = len(ts.buckets[ts.c])
+	}
+	ts.i--
+	return ts.u.MoveTo(ts.buckets[ts.c][ts.i], ts.u.Pivot), true
+}
+
+// All collects the remaining locations.
+func (ts *Targets) All() []hex.Unit {
+	us := []hex.Unit{}
+	for t, ok := ts.Next(); ok; t, ok = ts.Next() {
+		us = append(us, t)
+	}
+	return us
+}
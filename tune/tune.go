@@ -0,0 +1,123 @@
+// Package tune searches evaluator weights with a genetic algorithm, rating
+// each weight vector by the simulated total over a training set.
+package tune
+
+import "math"
+import "math/rand"
+import "sort"
+import "sync"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/solver"
+
+// Sample is one seed of a program to train on.
+type Sample struct {
+	Program game.Program
+	Seed    int
+}
+
+type Params struct {
+	Solver      string
+	BeamWidth   int
+	Population  int
+	Generations int
+	// Sigma is the standard deviation of mutations, relative to the size
+	// of the weight mutated.
+	Sigma float64
+	Jobs  int
+}
+
+// Individual is a weight vector with its total score.
+type Individual struct {
+	Weights solver.Weights
+	Score   int
+}
+
+// Fitness solves every sample with the weights and sums the scores
+// game.Simulate gives the solutions, solving up to jobs samples at once.
+func Fitness(samples []Sample, p Params, w solver.Weights) int {
+	s, err := solver.New(p.Solver, solver.Options{Weights: w, BeamWidth: p.BeamWidth})
+	if err != nil {
+		panic(err.Error())
+	}
+
+	scores := make([]int, len(samples))
+	next := make(chan int)
+	wg := sync.WaitGroup{}
+	for j := 0; j < p.Jobs || j == 0; j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				sol := s.Solve(solver.Input{Program: samples[i].Program, Seed: samples[i].Seed})
+				if r := game.Simulate(samples[i].Program, samples[i].Seed, sol.Commands); r.Err == nil {
+					scores[i] = r.Score
+				}
+			}
+		}()
+	}
+	for i := range samples {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	total := 0
+	for _, s := range scores {
+		total += s
+	}
+	return total
+}
+
+func mutate(r *rand.Rand, x, sigma float64) float64 {
+	return x + r.NormFloat64()*sigma*math.Max(math.Abs(x), 1)
+}
+
+func crossover(r *rand.Rand, a, b solver.Weights, sigma float64) solver.Weights {
+	pick := func(x, y float64) float64 {
+		if r.Intn(2) == 0 {
+			return mutate(r, x, sigma)
+		}
+		return mutate(r, y, sigma)
+	}
+	return solver.Weights{
+		Lines:     pick(a.Lines, b.Lines),
+		Height:    pick(a.Height, b.Height),
+		Holes:     pick(a.Holes, b.Holes),
+		Bumpiness: pick(a.Bumpiness, b.Bumpiness),
+		Dead:      pick(a.Dead, b.Dead),
+	}
+}
+
+// Evolve starts from a population of mutations of start and in every
+// generation keeps the best quarter, refilling the population with
+// mutated crossovers of them. It calls progress with the best individual
+// after each generation and returns the best one found.
+func Evolve(r *rand.Rand, samples []Sample, p Params, start solver.Weights, progress func(gen int, best Individual)) Individual {
+	pop := []Individual{Individual{Weights: start}}
+	for len(pop) < p.Population {
+		pop = append(pop, Individual{Weights: crossover(r, start, start, p.Sigma)})
+	}
+	for i := range pop {
+		pop[i].Score = Fitness(samples, p, pop[i].Weights)
+	}
+
+	elite := (p.Population + 3) / 4
+	for gen := 0; gen < p.Generations; gen++ {
+		sort.SliceStable(pop, func(a, b int) bool { return pop[a].Score > pop[b].Score })
+		if progress != nil {
+			progress(gen, pop[0])
+		}
+
+		pop = pop[:elite]
+		for len(pop) < p.Population {
+			a := pop[r.Intn(elite)]
+			b := pop[r.Intn(elite)]
+			w := crossover(r, a.Weights, b.Weights, p.Sigma)
+			pop = append(pop, Individual{Weights: w, Score: Fitness(samples, p, w)})
+		}
+	}
+
+	sort.SliceStable(pop, func(a, b int) bool { return pop[a].Score > pop[b].Score })
+	return pop[0]
+}
@@ -0,0 +1,40 @@
+package tune
+
+import "io/ioutil"
+import "math/rand"
+import "testing"
+
+import "github.com/mneise/icfp15/game"
+import "github.com/mneise/icfp15/solver"
+
+func TestEvolveNeverGetsWorse(t *testing.T) {
+	in, err := ioutil.ReadFile("../p0.json")
+	if err != nil {
+		t.Fatalf("can't open problem: %v", err)
+	}
+	samples := []Sample{Sample{Program: *game.ReadProgram(in), Seed: 0}}
+	p := Params{Solver: "heuristic", Population: 4, Generations: 2, Sigma: 0.5, Jobs: 2}
+
+	start := Fitness(samples, p, solver.DefaultWeights)
+	gens := 0
+	best := Evolve(rand.New(rand.NewSource(1)), samples, p, solver.DefaultWeights, func(gen int, b Individual) {
+		gens++
+		if b.Score < start {
+			t.Errorf("Generation %v got worse than the start: %v < %v", gen, b.Score, start)
+		}
+	})
+
+	if gens != p.Generations || best.Score < start {
+		t.Errorf("Expected %v generations improving on %v, got %v: %+v", p.Generations, start, gens, best)
+	}
+	if actual := Fitness(samples, p, best.Weights); actual != best.Score {
+		t.Errorf("Expected fitness to be reproducible, got %v expected %v", actual, best.Score)
+	}
+}
+
+func TestCrossoverKeepsAllWeights(t *testing.T) {
+	w := solver.Weights{Lines: 1, Height: 2, Holes: 3, Bumpiness: 4, Dead: 5}
+	if actual := crossover(rand.New(rand.NewSource(1)), w, w, 0); actual != w {
+		t.Errorf("Expected crossing %+v with itself unmutated to keep it, but was: %+v", w, actual)
+	}
+}